@@ -1,37 +1,222 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Uranury/RBK_fetchAPI/internal/cache"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	ListenAddr  string
-	SteamAPIKey string
-	RedisAddr   string
-	DB_URL      string
+	ListenAddr string
+	// SteamAPIKeys are used round-robin; a key Steam rejects as invalid, or
+	// rate limits while another key is usable, is sidelined for
+	// SteamKeyCooldown.
+	SteamAPIKeys     []string
+	SteamKeyCooldown time.Duration
+	SteamAPIURL      string
+	// SteamStoreURL is the base URL of the store API (app details).
+	SteamStoreURL string
+	// SteamRateLimit is the sustained number of outbound Steam calls per
+	// second; SteamRateBurst is how many may be made back to back.
+	SteamRateLimit float64
+	SteamRateBurst int
+	// SteamStoreRateLimit and SteamStoreRateBurst do the same for the store
+	// API, which Steam limits separately and more tightly.
+	SteamStoreRateLimit float64
+	SteamStoreRateBurst int
+	// SteamDailyBudget caps outbound Steam calls per API key and UTC day,
+	// matching Steam's per-key limit; 0 disables it.
+	SteamDailyBudget int
+	// Transient Steam failures are retried up to SteamRetryAttempts times in
+	// total, with jittered exponential backoff between SteamRetryBaseDelay
+	// and SteamRetryMaxDelay.
+	SteamRetryAttempts  int
+	SteamRetryBaseDelay time.Duration
+	SteamRetryMaxDelay  time.Duration
+	// An endpoint's circuit breaker opens after SteamBreakerThreshold
+	// consecutive failures and stays open for SteamBreakerCooldown.
+	SteamBreakerThreshold int
+	SteamBreakerCooldown  time.Duration
+	RedisAddr             string
+	DB_URL                string
+
+	// CacheBackend selects the cache implementation: "redis" or "memory".
+	CacheBackend string
+	// CacheSize caps the number of entries held by the in-memory cache.
+	CacheSize int
+	// CachePolicies maps a cache resource to its fresh/stale TTLs. Defaults
+	// come from defaultCachePolicies and can be overridden per resource with
+	// CACHE_POLICY_<RESOURCE>, e.g. CACHE_POLICY_GAME_SCHEMA="fresh=24h,stale=168h".
+	CachePolicies map[string]cache.Policy
+
+	// DegradeMissingRarity serves achievements with zero rarity instead of
+	// failing when Steam's global percentages endpoint is unavailable.
+	DegradeMissingRarity bool
+
+	// AchievementSummaryConcurrency bounds the games /achievements/summary
+	// fetches at once; AchievementSummaryBudget is how long it fetches
+	// before returning a partial summary.
+	AchievementSummaryConcurrency int
+	AchievementSummaryBudget      time.Duration
+
+	// PlayerSamplerAppIDs lists the apps whose current player count is
+	// recorded every PlayerSamplerInterval; empty disables the sampler.
+	PlayerSamplerAppIDs   []int
+	PlayerSamplerInterval time.Duration
 }
 
 func Load() *Config {
 	_ = loadEnv()
 
 	listenAddr := getEnv("LISTEN_ADDR", ":8080")
-	steamAPIKey := os.Getenv("STEAM_API_KEY")
+	steamAPIKeys := getListEnv("STEAM_API_KEYS")
+	if len(steamAPIKeys) == 0 {
+		steamAPIKeys = getListEnv("STEAM_API_KEY")
+	}
+	steamKeyCooldown := getDurationEnv("STEAM_KEY_COOLDOWN", 10*time.Minute)
+	steamAPIURL := getEnv("STEAM_API_BASE_URL", "https://api.steampowered.com")
+	steamStoreURL := getEnv("STEAM_STORE_BASE_URL", "https://store.steampowered.com")
+	steamRateLimit := getFloatEnv("STEAM_RATE_LIMIT", 5)
+	steamRateBurst := getIntEnv("STEAM_RATE_BURST", 10)
+	steamStoreRateLimit := getFloatEnv("STEAM_STORE_RATE_LIMIT", 0.5)
+	steamStoreRateBurst := getIntEnv("STEAM_STORE_RATE_BURST", 5)
+	steamDailyBudget := getIntEnv("STEAM_DAILY_BUDGET", 100000)
+	steamRetryAttempts := getIntEnv("STEAM_RETRY_ATTEMPTS", 3)
+	steamRetryBaseDelay := getDurationEnv("STEAM_RETRY_BASE_DELAY", 200*time.Millisecond)
+	steamRetryMaxDelay := getDurationEnv("STEAM_RETRY_MAX_DELAY", 5*time.Second)
+	steamBreakerThreshold := getIntEnv("STEAM_BREAKER_THRESHOLD", 5)
+	steamBreakerCooldown := getDurationEnv("STEAM_BREAKER_COOLDOWN", 30*time.Second)
 	redisAddr := os.Getenv("REDIS_ADDR")
 	db_url := os.Getenv("POSTGRES_DSN")
+	cacheBackend := getEnv("CACHE_BACKEND", "redis")
+	cacheSize := getIntEnv("CACHE_SIZE", 10000)
+	cachePolicies := loadCachePolicies()
+	degradeMissingRarity := getBoolEnv("DEGRADE_MISSING_RARITY", false)
+	achievementSummaryConcurrency := getIntEnv("ACHIEVEMENT_SUMMARY_CONCURRENCY", 4)
+	achievementSummaryBudget := getDurationEnv("ACHIEVEMENT_SUMMARY_BUDGET", 20*time.Second)
+	playerSamplerAppIDs := getIntListEnv("PLAYER_SAMPLER_APP_IDS")
+	playerSamplerInterval := getDurationEnv("PLAYER_SAMPLER_INTERVAL", 10*time.Minute)
 
-	if steamAPIKey == "" {
-		log.Fatal("STEAM_API_KEY is not set")
+	if len(steamAPIKeys) == 0 {
+		log.Fatal("STEAM_API_KEYS (or STEAM_API_KEY) is not set")
+	}
+	if playerSamplerInterval <= 0 {
+		log.Fatal("PLAYER_SAMPLER_INTERVAL must be positive")
+	}
+	if achievementSummaryConcurrency <= 0 {
+		log.Fatal("ACHIEVEMENT_SUMMARY_CONCURRENCY must be positive")
+	}
+	if achievementSummaryBudget <= 0 {
+		log.Fatal("ACHIEVEMENT_SUMMARY_BUDGET must be positive")
+	}
+	if cacheBackend != "redis" && cacheBackend != "memory" {
+		log.Fatalf("CACHE_BACKEND must be \"redis\" or \"memory\", got %q", cacheBackend)
 	}
 
 	return &Config{
-		ListenAddr:  listenAddr,
-		SteamAPIKey: steamAPIKey,
-		RedisAddr:   redisAddr,
-		DB_URL:      db_url,
+		ListenAddr:       listenAddr,
+		SteamAPIKeys:     steamAPIKeys,
+		SteamKeyCooldown: steamKeyCooldown,
+		SteamAPIURL:      steamAPIURL,
+		SteamStoreURL:    steamStoreURL,
+
+		SteamRateLimit:      steamRateLimit,
+		SteamRateBurst:      steamRateBurst,
+		SteamStoreRateLimit: steamStoreRateLimit,
+		SteamStoreRateBurst: steamStoreRateBurst,
+		SteamDailyBudget:    steamDailyBudget,
+
+		SteamRetryAttempts:    steamRetryAttempts,
+		SteamRetryBaseDelay:   steamRetryBaseDelay,
+		SteamRetryMaxDelay:    steamRetryMaxDelay,
+		SteamBreakerThreshold: steamBreakerThreshold,
+		SteamBreakerCooldown:  steamBreakerCooldown,
+
+		RedisAddr: redisAddr,
+		DB_URL:    db_url,
+
+		CacheBackend:  cacheBackend,
+		CacheSize:     cacheSize,
+		CachePolicies: cachePolicies,
+
+		DegradeMissingRarity: degradeMissingRarity,
+
+		AchievementSummaryConcurrency: achievementSummaryConcurrency,
+		AchievementSummaryBudget:      achievementSummaryBudget,
+
+		PlayerSamplerAppIDs:   playerSamplerAppIDs,
+		PlayerSamplerInterval: playerSamplerInterval,
+	}
+}
+
+var defaultCachePolicies = map[string]cache.Policy{
+	"vanity":                         {Fresh: 5 * time.Minute, Stale: 24 * time.Hour, Refresh: true},
+	"owned_games":                    {Fresh: 5 * time.Minute, Stale: time.Hour, Refresh: true},
+	"recent_games":                   {Fresh: 5 * time.Minute, Stale: time.Hour, Refresh: true},
+	"summary":                        {Fresh: 5 * time.Minute, Stale: time.Hour, Refresh: true},
+	"bans":                           {Fresh: time.Minute, Stale: 15 * time.Minute, Refresh: true},
+	"friends":                        {Fresh: 15 * time.Minute, Stale: 6 * time.Hour, Refresh: true},
+	"level":                          {Fresh: time.Hour, Stale: 24 * time.Hour, Refresh: true},
+	"badges":                         {Fresh: time.Hour, Stale: 24 * time.Hour, Refresh: true},
+	"player_achievements":            {Fresh: 5 * time.Minute, Stale: time.Hour, Refresh: true},
+	"fetched_player_achievements":    {Fresh: 5 * time.Minute, Stale: time.Hour, Refresh: true},
+	"achievement_summary":            {Fresh: 15 * time.Minute, Stale: 6 * time.Hour, Refresh: true},
+	"partial_achievement_summary":    {Fresh: time.Minute},
+	"user_stats":                     {Fresh: 5 * time.Minute, Stale: time.Hour, Refresh: true},
+	"current_players":                {Fresh: time.Minute, Stale: 10 * time.Minute, Refresh: true},
+	"news":                           {Fresh: 15 * time.Minute, Stale: 6 * time.Hour, Refresh: true},
+	"app_details":                    {Fresh: 6 * time.Hour, Stale: 48 * time.Hour, Refresh: true},
+	"game_schema":                    {Fresh: 336 * time.Hour, Stale: 336 * time.Hour, Refresh: true},
+	"global_achievement_percentages": {Fresh: 24 * time.Hour, Stale: 72 * time.Hour, Refresh: true},
+}
+
+func loadCachePolicies() map[string]cache.Policy {
+	policies := make(map[string]cache.Policy, len(defaultCachePolicies))
+	for resource, policy := range defaultCachePolicies {
+		key := "CACHE_POLICY_" + strings.ToUpper(resource)
+		if val := os.Getenv(key); val != "" {
+			var err error
+			if policy, err = parseCachePolicy(val, policy); err != nil {
+				log.Fatalf("%s: %v", key, err)
+			}
+		}
+		policies[resource] = policy
 	}
+	return policies
+}
+
+// parseCachePolicy applies comma-separated overrides such as
+// "fresh=5m,stale=1h,refresh=false" on top of base.
+func parseCachePolicy(val string, base cache.Policy) (cache.Policy, error) {
+	policy := base
+	for _, field := range strings.Split(val, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return policy, fmt.Errorf("expected name=value, got %q", field)
+		}
+
+		var err error
+		switch name {
+		case "fresh":
+			policy.Fresh, err = time.ParseDuration(value)
+		case "stale":
+			policy.Stale, err = time.ParseDuration(value)
+		case "refresh":
+			policy.Refresh, err = strconv.ParseBool(value)
+		default:
+			return policy, fmt.Errorf("unknown field %q", name)
+		}
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return policy, nil
 }
 
 func getEnv(key, fallback string) string {
@@ -41,6 +226,77 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getListEnv splits a comma-separated variable, dropping empty items.
+func getListEnv(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func getIntListEnv(key string) []int {
+	var items []int
+	for _, item := range getListEnv(key) {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			log.Fatalf("%s: invalid integer %q", key, item)
+		}
+		items = append(items, n)
+	}
+	return items
+}
+
+func getIntEnv(key string, fallback int) int {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		log.Fatalf("%s must be an integer, got %q", key, val)
+	}
+	return n
+}
+
+func getFloatEnv(key string, fallback float64) float64 {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		log.Fatalf("%s must be a number, got %q", key, val)
+	}
+	return f
+}
+
+func getDurationEnv(key string, fallback time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		log.Fatalf("%s must be a duration such as 500ms or 30s, got %q", key, val)
+	}
+	return d
+}
+
+func getBoolEnv(key string, fallback bool) bool {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		log.Fatalf("%s must be a boolean, got %q", key, val)
+	}
+	return b
+}
+
 func loadEnv() error {
 	return godotenv.Load()
 }
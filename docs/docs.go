@@ -27,7 +27,7 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Steam ID of the user",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name of the user",
                         "name": "steamID",
                         "in": "query",
                         "required": true
@@ -38,6 +38,12 @@ const docTemplate = `{
                         "name": "appID",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to hide descriptions of hidden achievements the user hasn't unlocked",
+                        "name": "revealHidden",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -71,19 +77,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/games": {
+        "/achievements/summary": {
             "get": {
+                "description": "Covers every owned game with community stats: per-game unlocked/total, the overall completion rate, perfect games and the rarest unlocked achievements. Large libraries are fetched over several requests: while complete is false, pendingGames games are still being fetched and the summary fills in on later requests.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "gamesInfo"
                 ],
-                "summary": "returns user's owned games",
+                "summary": "returns the user's achievement progress across their whole library",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Steam ID",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name of the user",
                         "name": "steamID",
                         "in": "query",
                         "required": true
@@ -93,7 +100,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.OwnedGamesResponse"
+                            "$ref": "#/definitions/models.AchievementSummary"
                         }
                     },
                     "400": {
@@ -114,32 +121,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/steam_id": {
+        "/admin/quota": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "steamProfile"
+                    "admin"
                 ],
-                "summary": "Retrieve steamID under vanityID if it exists",
+                "summary": "returns today's Steam API usage of each key against the daily per-key budget",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuotaUsage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/apps/{appID}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apps"
+                ],
+                "summary": "returns a game's store page with localized pricing",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Vanity URL",
-                        "name": "vanity",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code for prices, e.g. us",
+                        "name": "cc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language, e.g. english",
+                        "name": "l",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.App"
                         }
                     },
                     "400": {
@@ -166,29 +207,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/summary": {
+        "/apps/{appID}/achievements/global": {
             "get": {
+                "description": "Achievements come in the game's own order unless sort=rarity is given; order=asc (default) lists the rarest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "steamProfile"
+                    "apps"
                 ],
-                "summary": "returns general info about the user",
+                "summary": "returns every achievement of a game with its global unlock percentage",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Steam ID",
-                        "name": "steamID",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "rarity"
+                        ],
+                        "type": "string",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Summary"
+                            "$ref": "#/definitions/models.GameAchievements"
                         }
                     },
                     "400": {
@@ -200,11 +261,69 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/apperrors.APIError"
                         }
+                    }
+                }
+            }
+        },
+        "/apps/{appID}/news": {
+            "get": {
+                "description": "Returns JSON by default, or an RSS 2.0 or Atom feed when the Accept header asks for application/rss+xml or application/atom+xml.",
+                "produces": [
+                    "application/json",
+                    "application/rss+xml",
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "apps"
+                ],
+                "summary": "returns a game's news feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items, up to 100 (Steam's default is 20)",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Truncate contents to this many characters; 0 means full contents",
+                        "name": "maxlength",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated feed names, e.g. steam_community_announcements",
+                        "name": "feeds",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AppNews"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -214,177 +333,2001 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "apperrors.APIError": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "statusCode": {
-                    "type": "integer"
-                }
-            }
-        },
-        "models.Achievement": {
-            "type": "object",
-            "properties": {
-                "achieved": {
-                    "type": "boolean"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "displayName": {
-                    "type": "string"
-                },
-                "icon": {
-                    "type": "string"
-                },
-                "iconGray": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "rarity": {
-                    "description": "Percentage of players who have this achievement",
-                    "type": "number"
-                },
-                "unlockTime": {
-                    "type": "string"
-                }
-            }
         },
-        "models.OwnedGamesResponse": {
-            "type": "object",
-            "properties": {
-                "response": {
-                    "type": "object",
-                    "properties": {
-                        "game_count": {
-                            "type": "integer"
-                        },
-                        "games": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "properties": {
-                                    "appid": {
-                                        "type": "integer"
-                                    },
-                                    "has_community_visible_stats": {
-                                        "type": "boolean"
-                                    },
-                                    "img_icon_url": {
-                                        "type": "string"
-                                    },
-                                    "img_logo_url": {
-                                        "type": "string"
-                                    },
-                                    "name": {
-                                        "type": "string"
-                                    },
-                                    "playtime_forever": {
-                                        "type": "integer"
-                                    }
-                                }
+        "/apps/{appID}/players": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apps"
+                ],
+                "summary": "returns how many players are in a game right now",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CurrentPlayers"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
                     }
                 }
             }
         },
-        "models.PlayerAchievements": {
-            "type": "object",
-            "properties": {
-                "achievements": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Achievement"
+        "/apps/{appID}/players/history": {
+            "get": {
+                "description": "Only apps listed in PLAYER_SAMPLER_APP_IDS are sampled. Buckets are aligned to the unix epoch and empty buckets are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apps"
+                ],
+                "summary": "returns a game's sampled player counts aggregated per bucket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "App ID",
+                        "name": "appID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time, RFC 3339 (default: 24h before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time, RFC 3339 (default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket width as a Go duration, at least 1m (default: 1h)",
+                        "name": "bucket",
+                        "in": "query"
                     }
-                },
-                "gameName": {
-                    "type": "string"
-                },
-                "steamID": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.Summary": {
-            "type": "object",
-            "properties": {
-                "response": {
-                    "type": "object",
-                    "properties": {
-                        "players": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "properties": {
-                                    "avatar": {
-                                        "type": "string"
-                                    },
-                                    "avatarfull": {
-                                        "type": "string"
-                                    },
-                                    "avatarhash": {
-                                        "type": "string"
-                                    },
-                                    "avatarmedium": {
-                                        "type": "string"
-                                    },
-                                    "commentpermission": {
-                                        "type": "integer"
-                                    },
-                                    "communityvisibilitystate": {
-                                        "type": "integer"
-                                    },
-                                    "lastlogoff": {
-                                        "type": "integer"
-                                    },
-                                    "loccountrycode": {
-                                        "type": "string"
-                                    },
-                                    "locstatecode": {
-                                        "type": "string"
-                                    },
-                                    "personaname": {
-                                        "type": "string"
-                                    },
-                                    "personastate": {
-                                        "type": "integer"
-                                    },
-                                    "personastateflags": {
-                                        "type": "integer"
-                                    },
-                                    "primaryclanid": {
-                                        "type": "string"
-                                    },
-                                    "profilestate": {
-                                        "type": "integer"
-                                    },
-                                    "profileurl": {
-                                        "type": "string"
-                                    },
-                                    "realname": {
-                                        "type": "string"
-                                    },
-                                    "steamid": {
-                                        "type": "string"
-                                    },
-                                    "timecreated": {
-                                        "type": "integer"
-                                    }
-                                }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PlayerCountHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
                     }
                 }
             }
+        },
+        "/badges": {
+            "get": {
+                "description": "Also includes the user's level and XP. Fails with 409 when the profile is private.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steamProfile"
+                ],
+                "summary": "returns the user's badges with their completion times",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name",
+                        "name": "steamID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PlayerBadges"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/bans": {
+            "get": {
+                "description": "Accepts up to 1000 repeated or comma-separated steamIDs. The response is keyed by SteamID64; ids that couldn't be found carry an error instead of a ban record. If Steam fails for every id, its error is returned instead. Vanity names and /id/ URLs are not accepted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steamProfile"
+                ],
+                "summary": "returns VAC, game, community and trade ban status of many users",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "SteamID64, SteamID2, SteamID3 or profile URL values",
+                        "name": "steamIDs",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/models.PlayerBansResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/friends": {
+            "get": {
+                "description": "Pass expand=summary to include each friend's player summary. Fails with 409 when the friend list is not public.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steamProfile"
+                ],
+                "summary": "returns the user's friends and when each friendship started",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name",
+                        "name": "steamID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "summary"
+                        ],
+                        "type": "string",
+                        "description": "Set to summary to include friend summaries",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FriendList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/games": {
+            "get": {
+                "description": "Filters, sorting and paging run over the cached library. game_count is the size of the whole library and total the number of games matching the filters. Without limit every match is returned. Playtimes are in minutes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gamesInfo"
+                ],
+                "summary": "returns user's owned games",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name",
+                        "name": "steamID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "playtime",
+                            "name",
+                            "appid",
+                            "last_played"
+                        ],
+                        "type": "string",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order; defaults to desc for playtime and last_played, asc otherwise",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum playtime in minutes, inclusive",
+                        "name": "minPlaytime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum playtime in minutes, inclusive",
+                        "name": "maxPlaytime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true for games played at least once, false for never played",
+                        "name": "played",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive name search",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of matching games to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OwnedGamesPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/stats": {
+            "get": {
+                "description": "Totals, median playtime, never-played games, the most played games, a playtime distribution and the share of games with community stats. Playtimes are in minutes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gamesInfo"
+                ],
+                "summary": "returns analytics over the user's owned games",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name",
+                        "name": "steamID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of most played games to list (default 10, max 100)",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LibraryStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "status is \"degraded\" while any breaker is not closed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "reports service health and the state of each Steam endpoint's circuit breaker",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Health"
+                        }
+                    }
+                }
+            }
+        },
+        "/level": {
+            "get": {
+                "description": "Fails with 409 when the profile is private.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steamProfile"
+                ],
+                "summary": "returns the user's Steam level and XP",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name",
+                        "name": "steamID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PlayerLevel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recent": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gamesInfo"
+                ],
+                "summary": "returns games the user played in the last two weeks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name",
+                        "name": "steamID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecentlyPlayedGamesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Stats are labelled with the game schema's display names; stats Steam leaves out are reported at their default value. Fails with 409 for private profiles and games without stats.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gamesInfo"
+                ],
+                "summary": "returns the user's numeric stats in a game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name of the user",
+                        "name": "steamID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "App ID of the game",
+                        "name": "appID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PlayerStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/steam_id": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steamProfile"
+                ],
+                "summary": "Retrieve steamID under vanityID if it exists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vanity URL",
+                        "name": "vanity",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/steam_id/convert": {
+            "get": {
+                "description": "Accepts a SteamID64, SteamID2, SteamID3, profile URL or vanity name of any account type, including groups. steamID2 is only set for individual accounts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steamProfile"
+                ],
+                "summary": "returns every representation of a SteamID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SteamIDConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/summaries": {
+            "get": {
+                "description": "Accepts up to 1000 steamIDs, either as a JSON body (POST) or as repeated or comma-separated steamIDs query values (GET). The response is keyed by SteamID64; ids that couldn't be found carry an error instead of a player. If Steam fails for every id, its error is returned instead. Vanity names and /id/ URLs are not accepted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steamProfile"
+                ],
+                "summary": "returns general info about many users at once",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "SteamID64, SteamID2, SteamID3 or profile URL values",
+                        "name": "steamIDs",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Steam IDs",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.PlayerSummariesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/models.PlayerSummaryResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Accepts up to 1000 steamIDs, either as a JSON body (POST) or as repeated or comma-separated steamIDs query values (GET). The response is keyed by SteamID64; ids that couldn't be found carry an error instead of a player. If Steam fails for every id, its error is returned instead. Vanity names and /id/ URLs are not accepted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steamProfile"
+                ],
+                "summary": "returns general info about many users at once",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "SteamID64, SteamID2, SteamID3 or profile URL values",
+                        "name": "steamIDs",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Steam IDs",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.PlayerSummariesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/models.PlayerSummaryResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/summary": {
+            "get": {
+                "description": "Returns the decoded player profile. Pass raw=true to get Steam's original GetPlayerSummaries payload (models.Summary) instead; include is ignored in that mode.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "steamProfile"
+                ],
+                "summary": "returns general info about the user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SteamID64, SteamID2, SteamID3, profile URL or vanity name",
+                        "name": "steamID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return Steam's raw payload",
+                        "name": "raw",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "bans"
+                        ],
+                        "type": "string",
+                        "description": "Comma-separated extra blocks to include; bans adds the player's ban record",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Player"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.APIError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apperrors.APIError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "statusCode": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Achievement": {
+            "type": "object",
+            "properties": {
+                "achieved": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "iconGray": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orphaned": {
+                    "description": "Orphaned marks achievements the player has but the game schema no\nlonger lists; only Name, Achieved, UnlockTime and Rarity are known.",
+                    "type": "boolean"
+                },
+                "rarity": {
+                    "description": "Percentage of players who have this achievement",
+                    "type": "number"
+                },
+                "unlockTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementSummary": {
+            "type": "object",
+            "properties": {
+                "averageCompletion": {
+                    "type": "number"
+                },
+                "complete": {
+                    "description": "Complete is false when some games could not be fetched in time; they\nare counted in PendingGames and fill in on a later request as their\nresults reach the cache.",
+                    "type": "boolean"
+                },
+                "completionRate": {
+                    "description": "CompletionRate is Unlocked over Total; AverageCompletion is the mean\nof the per-game percentages of games with at least one unlock, which\nis what Steam shows on profiles.",
+                    "type": "number"
+                },
+                "games": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GameCompletion"
+                    }
+                },
+                "pendingGames": {
+                    "type": "integer"
+                },
+                "perfectGames": {
+                    "type": "integer"
+                },
+                "rarestUnlocked": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RareAchievement"
+                    }
+                },
+                "skippedGames": {
+                    "description": "SkippedGames counts games Steam returned no achievements for.",
+                    "type": "integer"
+                },
+                "steamID": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unlocked": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.App": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "developers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "headerImage": {
+                    "type": "string"
+                },
+                "isFree": {
+                    "type": "boolean"
+                },
+                "metacritic": {
+                    "$ref": "#/definitions/models.Metacritic"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "platforms": {
+                    "$ref": "#/definitions/models.Platforms"
+                },
+                "price": {
+                    "description": "Price is missing for free apps and apps not sold in the country.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Price"
+                        }
+                    ]
+                },
+                "publishers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "releaseDate": {
+                    "$ref": "#/definitions/models.ReleaseDate"
+                },
+                "shortDescription": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "game, dlc, demo, music...",
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AppNews": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NewsItem"
+                    }
+                },
+                "total": {
+                    "description": "Total is how many news items Steam has for the app, not how many are\nreturned.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Badge": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "description": "AppID is set for game badges (trading cards) only.",
+                    "type": "integer"
+                },
+                "badgeID": {
+                    "type": "integer"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "foil": {
+                    "type": "boolean"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "scarcity": {
+                    "description": "how many players own the badge",
+                    "type": "integer"
+                },
+                "xp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BreakerStatus": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer"
+                },
+                "openedAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CurrentPlayers": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "integer"
+                },
+                "players": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.EconomyBan": {
+            "type": "string",
+            "enum": [
+                "none",
+                "probation",
+                "banned"
+            ],
+            "x-enum-varnames": [
+                "EconomyBanNone",
+                "EconomyBanProbation",
+                "EconomyBanBanned"
+            ]
+        },
+        "models.Friend": {
+            "type": "object",
+            "properties": {
+                "friendSince": {
+                    "type": "string"
+                },
+                "player": {
+                    "description": "Player is only filled in when the list is expanded with summaries.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Player"
+                        }
+                    ]
+                },
+                "relationship": {
+                    "type": "string"
+                },
+                "steamID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FriendList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Friend"
+                    }
+                },
+                "steamID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GameAchievements": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GlobalAchievement"
+                    }
+                },
+                "appID": {
+                    "type": "string"
+                },
+                "gameName": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GameCompletion": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unlocked": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GlobalAchievement": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "iconGray": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Health": {
+            "type": "object",
+            "properties": {
+                "breakers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.BreakerStatus"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.KeyQuotaUsage": {
+            "type": "object",
+            "properties": {
+                "endpoints": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "key": {
+                    "description": "the key's last four characters",
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LibraryStats": {
+            "type": "object",
+            "properties": {
+                "communityStatsShare": {
+                    "type": "number"
+                },
+                "distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlaytimeBucket"
+                    }
+                },
+                "gamesWithCommunityStats": {
+                    "type": "integer"
+                },
+                "medianPlaytime": {
+                    "description": "MedianPlaytime is taken over every owned game, played or not.",
+                    "type": "number"
+                },
+                "neverPlayed": {
+                    "description": "NeverPlayed counts owned games with no recorded playtime, the \"pile\nof shame\".",
+                    "type": "integer"
+                },
+                "neverPlayedShare": {
+                    "type": "number"
+                },
+                "steamID": {
+                    "type": "string"
+                },
+                "topGames": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OwnedGame"
+                    }
+                },
+                "totalGames": {
+                    "type": "integer"
+                },
+                "totalPlaytime": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Metacritic": {
+            "type": "object",
+            "properties": {
+                "score": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NewsItem": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "contents": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "external": {
+                    "type": "boolean"
+                },
+                "feedLabel": {
+                    "type": "string"
+                },
+                "feedName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OwnedGame": {
+            "type": "object",
+            "properties": {
+                "appid": {
+                    "type": "integer"
+                },
+                "has_community_visible_stats": {
+                    "type": "boolean"
+                },
+                "img_icon_url": {
+                    "type": "string"
+                },
+                "img_logo_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "playtime_2weeks": {
+                    "type": "integer"
+                },
+                "playtime_deck_forever": {
+                    "type": "integer"
+                },
+                "playtime_disconnected": {
+                    "type": "integer"
+                },
+                "playtime_forever": {
+                    "type": "integer"
+                },
+                "playtime_linux_forever": {
+                    "type": "integer"
+                },
+                "playtime_mac_forever": {
+                    "type": "integer"
+                },
+                "playtime_windows_forever": {
+                    "type": "integer"
+                },
+                "rtime_last_played": {
+                    "description": "unix seconds, 0 when never played",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OwnedGamesPage": {
+            "type": "object",
+            "properties": {
+                "response": {
+                    "type": "object",
+                    "properties": {
+                        "game_count": {
+                            "type": "integer"
+                        },
+                        "games": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OwnedGame"
+                            }
+                        },
+                        "offset": {
+                            "type": "integer"
+                        },
+                        "total": {
+                            "type": "integer"
+                        }
+                    }
+                }
+            }
+        },
+        "models.PersonaState": {
+            "type": "string",
+            "enum": [
+                "offline",
+                "online",
+                "busy",
+                "away",
+                "snooze",
+                "looking_to_trade",
+                "looking_to_play",
+                "unknown"
+            ],
+            "x-enum-varnames": [
+                "PersonaOffline",
+                "PersonaOnline",
+                "PersonaBusy",
+                "PersonaAway",
+                "PersonaSnooze",
+                "PersonaLookingToTrade",
+                "PersonaLookingToPlay",
+                "PersonaUnknown"
+            ]
+        },
+        "models.Platforms": {
+            "type": "object",
+            "properties": {
+                "linux": {
+                    "type": "boolean"
+                },
+                "mac": {
+                    "type": "boolean"
+                },
+                "windows": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Player": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "$ref": "#/definitions/models.PlayerAvatar"
+                },
+                "bans": {
+                    "description": "Bans is only filled in when explicitly requested.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PlayerBans"
+                        }
+                    ]
+                },
+                "commentsAllowed": {
+                    "type": "boolean"
+                },
+                "countryCode": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customURL": {
+                    "description": "Steam's vanity URL, if the player set one",
+                    "type": "string"
+                },
+                "lastLogoff": {
+                    "type": "string"
+                },
+                "personaName": {
+                    "type": "string"
+                },
+                "personaState": {
+                    "$ref": "#/definitions/models.PersonaState"
+                },
+                "primaryClanID": {
+                    "type": "string"
+                },
+                "profileConfigured": {
+                    "type": "boolean"
+                },
+                "profileURL": {
+                    "type": "string"
+                },
+                "realName": {
+                    "type": "string"
+                },
+                "stateCode": {
+                    "type": "string"
+                },
+                "steamID": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "$ref": "#/definitions/models.Visibility"
+                }
+            }
+        },
+        "models.PlayerAchievements": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Achievement"
+                    }
+                },
+                "gameName": {
+                    "type": "string"
+                },
+                "rarityUnavailable": {
+                    "description": "RarityUnavailable is set when global percentages could not be fetched\nand every Rarity was left at 0.",
+                    "type": "boolean"
+                },
+                "steamID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerAvatar": {
+            "type": "object",
+            "properties": {
+                "full": {
+                    "type": "string"
+                },
+                "medium": {
+                    "type": "string"
+                },
+                "small": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerBadges": {
+            "type": "object",
+            "properties": {
+                "badges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Badge"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "steamID": {
+                    "type": "string"
+                },
+                "xp": {
+                    "type": "integer"
+                },
+                "xpCurrentLevel": {
+                    "description": "XPCurrentLevel is the total XP the current level required.",
+                    "type": "integer"
+                },
+                "xpToNextLevel": {
+                    "description": "XPToNextLevel is how much more XP the player needs to level up.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlayerBans": {
+            "type": "object",
+            "properties": {
+                "communityBanned": {
+                    "type": "boolean"
+                },
+                "daysSinceLastBan": {
+                    "description": "DaysSinceLastBan is 0 both for players banned today and for players\nthat were never banned.",
+                    "type": "integer"
+                },
+                "economyBan": {
+                    "$ref": "#/definitions/models.EconomyBan"
+                },
+                "gameBans": {
+                    "type": "integer"
+                },
+                "steamID": {
+                    "type": "string"
+                },
+                "vacBanned": {
+                    "type": "boolean"
+                },
+                "vacBans": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlayerBansResult": {
+            "type": "object",
+            "properties": {
+                "bans": {
+                    "$ref": "#/definitions/models.PlayerBans"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerCountBucket": {
+            "type": "object",
+            "properties": {
+                "avg": {
+                    "type": "number"
+                },
+                "max": {
+                    "type": "integer"
+                },
+                "min": {
+                    "type": "integer"
+                },
+                "samples": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerCountHistory": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "integer"
+                },
+                "bucket": {
+                    "type": "string"
+                },
+                "buckets": {
+                    "description": "Buckets without samples are left out.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerCountBucket"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerLevel": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "integer"
+                },
+                "steamID": {
+                    "type": "string"
+                },
+                "xp": {
+                    "type": "integer"
+                },
+                "xpCurrentLevel": {
+                    "description": "XPCurrentLevel is the total XP the current level required.",
+                    "type": "integer"
+                },
+                "xpToNextLevel": {
+                    "description": "XPToNextLevel is how much more XP the player needs to level up.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlayerStat": {
+            "type": "object",
+            "properties": {
+                "defaultValue": {
+                    "type": "number"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PlayerStats": {
+            "type": "object",
+            "properties": {
+                "appID": {
+                    "type": "string"
+                },
+                "gameName": {
+                    "type": "string"
+                },
+                "stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerStat"
+                    }
+                },
+                "steamID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerSummariesRequest": {
+            "type": "object",
+            "properties": {
+                "steamIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.PlayerSummary": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "avatarfull": {
+                    "type": "string"
+                },
+                "avatarhash": {
+                    "type": "string"
+                },
+                "avatarmedium": {
+                    "type": "string"
+                },
+                "commentpermission": {
+                    "type": "integer"
+                },
+                "communityvisibilitystate": {
+                    "type": "integer"
+                },
+                "lastlogoff": {
+                    "type": "integer"
+                },
+                "loccountrycode": {
+                    "type": "string"
+                },
+                "locstatecode": {
+                    "type": "string"
+                },
+                "personaname": {
+                    "type": "string"
+                },
+                "personastate": {
+                    "type": "integer"
+                },
+                "personastateflags": {
+                    "type": "integer"
+                },
+                "primaryclanid": {
+                    "type": "string"
+                },
+                "profilestate": {
+                    "type": "integer"
+                },
+                "profileurl": {
+                    "type": "string"
+                },
+                "realname": {
+                    "type": "string"
+                },
+                "steamid": {
+                    "type": "string"
+                },
+                "timecreated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlayerSummaryResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "player": {
+                    "$ref": "#/definitions/models.PlayerSummary"
+                }
+            }
+        },
+        "models.PlaytimeBucket": {
+            "type": "object",
+            "properties": {
+                "games": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "maxPlaytime": {
+                    "type": "integer"
+                },
+                "minPlaytime": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Price": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "discountPercent": {
+                    "type": "integer"
+                },
+                "final": {
+                    "type": "integer"
+                },
+                "finalFormatted": {
+                    "type": "string"
+                },
+                "initial": {
+                    "type": "integer"
+                },
+                "initialFormatted": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QuotaUsage": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "endpoints": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "keys": {
+                    "description": "by key id",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.KeyQuotaUsage"
+                    }
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RareAchievement": {
+            "type": "object",
+            "properties": {
+                "achieved": {
+                    "type": "boolean"
+                },
+                "appID": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "gameName": {
+                    "type": "string"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "iconGray": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orphaned": {
+                    "description": "Orphaned marks achievements the player has but the game schema no\nlonger lists; only Name, Achieved, UnlockTime and Rarity are known.",
+                    "type": "boolean"
+                },
+                "rarity": {
+                    "description": "Percentage of players who have this achievement",
+                    "type": "number"
+                },
+                "unlockTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RecentlyPlayedGamesResponse": {
+            "type": "object",
+            "properties": {
+                "response": {
+                    "type": "object",
+                    "properties": {
+                        "games": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OwnedGame"
+                            }
+                        },
+                        "total_count": {
+                            "type": "integer"
+                        }
+                    }
+                }
+            }
+        },
+        "models.ReleaseDate": {
+            "type": "object",
+            "properties": {
+                "comingSoon": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "description": "Date is the store's localized, free-form release date.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SteamIDConversion": {
+            "type": "object",
+            "properties": {
+                "accountID": {
+                    "type": "integer"
+                },
+                "accountType": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "integer"
+                },
+                "profileURL": {
+                    "type": "string"
+                },
+                "steamID2": {
+                    "description": "only individual accounts have one",
+                    "type": "string"
+                },
+                "steamID3": {
+                    "type": "string"
+                },
+                "steamID64": {
+                    "type": "string"
+                },
+                "universe": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Visibility": {
+            "type": "string",
+            "enum": [
+                "private",
+                "friends_only",
+                "public",
+                "unknown"
+            ],
+            "x-enum-varnames": [
+                "VisibilityPrivate",
+                "VisibilityFriendsOnly",
+                "VisibilityPublic",
+                "VisibilityUnknown"
+            ]
         }
     }
 }`
@@ -396,7 +2339,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "Steam API Wrapper",
-	Description:      "This is a service that resolves Steam vanity URLs and returns SteamIDs.",
+	Description:      "A lightweight service that integrates with the Steam Web API to fetch user profiles, owned games, and achievement data.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 }
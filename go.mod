@@ -13,6 +13,8 @@ require (
 	github.com/swaggo/files v1.0.1
 	github.com/swaggo/gin-swagger v1.6.0
 	github.com/swaggo/swag v1.8.12
+	golang.org/x/sync v0.12.0
+	golang.org/x/time v0.11.0
 )
 
 require (
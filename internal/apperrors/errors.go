@@ -1,6 +1,9 @@
 package apperrors
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type APIError struct {
 	StatusCode int
@@ -18,3 +21,16 @@ func NewAPIError(statusCode int, msg string) *APIError {
 func WrapAPIError(status int, err error, context string) *APIError {
 	return NewAPIError(status, fmt.Sprintf("%s: %v", context, err))
 }
+
+// AsAPIError returns err as an *APIError, wrapping anything that isn't one
+// already as a 500.
+func AsAPIError(err error) *APIError {
+	if err == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return WrapAPIError(500, err, "internal error")
+}
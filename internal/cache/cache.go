@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned by Get and TTL when the key does not exist or has expired.
+var ErrMiss = errors.New("cache: key not found")
+
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	TTL(ctx context.Context, key string) (time.Duration, error)
+}
+
+// GetJSON reads key and unmarshals it into a new T.
+func GetJSON[T any](ctx context.Context, c Cache, key string) (*T, error) {
+	data, err := c.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// SetJSON marshals value and stores it under key for ttl.
+func SetJSON(ctx context.Context, c Cache, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.Set(ctx, key, data, ttl)
+}
+
+// Policy describes how long a cached resource is served. An entry is fresh
+// for Fresh after it was stored, then stale for another Stale. Stale entries
+// are served immediately while a background refresh runs (when Refresh is
+// set) and as a fallback whenever the upstream fetch fails.
+type Policy struct {
+	Fresh   time.Duration
+	Stale   time.Duration
+	Refresh bool
+}
+
+// TTL is how long the backend needs to keep the entry around.
+func (p Policy) TTL() time.Duration {
+	return p.Fresh + p.Stale
+}
@@ -0,0 +1,65 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache(2)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), 0))
+
+	// Touch "a" so that "b" becomes the eviction candidate.
+	_, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), 0))
+
+	_, err = c.Get(ctx, "b")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+
+	value, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache(10)
+
+	require.NoError(t, c.Set(ctx, "short", []byte("x"), time.Millisecond))
+	require.NoError(t, c.Set(ctx, "long", []byte("y"), time.Hour))
+	time.Sleep(5 * time.Millisecond)
+
+	_, err := c.Get(ctx, "short")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+
+	ttl, err := c.TTL(ctx, "long")
+	require.NoError(t, err)
+	assert.InDelta(t, time.Hour, ttl, float64(time.Second))
+
+	require.NoError(t, c.Delete(ctx, "long"))
+	_, err = c.TTL(ctx, "long")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+}
+
+func TestJSONHelpers(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache(10)
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	require.NoError(t, cache.SetJSON(ctx, c, "p", payload{Name: "Terraria"}, time.Minute))
+	got, err := cache.GetJSON[payload](ctx, c, "p")
+	require.NoError(t, err)
+	assert.Equal(t, "Terraria", got.Name)
+}
@@ -0,0 +1,129 @@
+package clients
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+)
+
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half-open"
+)
+
+// Breakers holds one circuit breaker per Steam endpoint. A breaker opens
+// after threshold consecutive failures and rejects calls until cooldown has
+// passed; it then lets a single trial call through (half-open) and closes
+// again if that call succeeds.
+type Breakers struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+type breaker struct {
+	state    string
+	failures int
+	openedAt time.Time
+}
+
+func NewBreakers(threshold int, cooldown time.Duration) *Breakers {
+	return &Breakers{
+		threshold: threshold,
+		cooldown:  cooldown,
+		breakers:  make(map[string]*breaker),
+	}
+}
+
+// allow reports whether a call to endpoint may be made right now.
+func (b *Breakers) allow(endpoint string) bool {
+	if b == nil || b.threshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.get(endpoint)
+	switch br.state {
+	case breakerOpen:
+		if time.Since(br.openedAt) < b.cooldown {
+			return false
+		}
+		br.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// A trial call is already in flight
+		return false
+	}
+	return true
+}
+
+// record feeds the outcome of a call to endpoint into its breaker.
+func (b *Breakers) record(endpoint string, success bool) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.get(endpoint)
+	if success {
+		br.state = breakerClosed
+		br.failures = 0
+		return
+	}
+
+	br.failures++
+	if br.state == breakerHalfOpen || br.failures >= b.threshold {
+		br.state = breakerOpen
+		br.openedAt = time.Now()
+	}
+}
+
+// release undoes allow for a call that never reached Steam, so a half-open
+// breaker can hand its trial slot to the next caller.
+func (b *Breakers) release(endpoint string) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if br := b.get(endpoint); br.state == breakerHalfOpen {
+		br.state = breakerOpen
+	}
+}
+
+// Status returns a snapshot of every breaker that has seen traffic.
+func (b *Breakers) Status() map[string]models.BreakerStatus {
+	status := make(map[string]models.BreakerStatus)
+	if b == nil {
+		return status
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for endpoint, br := range b.breakers {
+		s := models.BreakerStatus{State: br.state, Failures: br.failures}
+		if br.state != breakerClosed {
+			openedAt := br.openedAt
+			s.OpenedAt = &openedAt
+		}
+		status[endpoint] = s
+	}
+	return status
+}
+
+// get returns the breaker for endpoint, creating it closed. Callers must hold b.mu.
+func (b *Breakers) get(endpoint string) *breaker {
+	br, ok := b.breakers[endpoint]
+	if !ok {
+		br = &breaker{state: breakerClosed}
+		b.breakers[endpoint] = br
+	}
+	return br
+}
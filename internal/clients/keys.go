@@ -0,0 +1,148 @@
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+)
+
+// keyRing hands out Steam API keys round-robin. A key Steam rejects as
+// invalid, or that is rate limited while another key is usable, is sidelined
+// for cooldown and skipped until it expires.
+type keyRing struct {
+	cooldown time.Duration
+
+	mu   sync.Mutex
+	keys []*apiKey
+	next int
+}
+
+type apiKey struct {
+	value          string
+	sidelinedUntil time.Time
+}
+
+// id identifies a key in logs, request history and quota counters without
+// revealing it.
+func (k *apiKey) id() string {
+	return KeyID(k.value)
+}
+
+// KeyID returns the id under which an API key is counted and recorded: a
+// short hash of the key, so keys that happen to share a suffix stay apart.
+func KeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "key-" + hex.EncodeToString(sum[:4])
+}
+
+// MaskKey returns how an API key is shown to people: its last four
+// characters. Unlike KeyID it need not be unique.
+func MaskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+func newKeyRing(values []string, cooldown time.Duration) *keyRing {
+	ring := &keyRing{cooldown: cooldown}
+	for _, value := range values {
+		ring.keys = append(ring.keys, &apiKey{value: value})
+	}
+	return ring
+}
+
+func (r *keyRing) size() int {
+	return len(r.keys)
+}
+
+// pick returns the next key that isn't sidelined.
+func (r *keyRing) pick() (*apiKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for i := 0; i < len(r.keys); i++ {
+		key := r.keys[(r.next+i)%len(r.keys)]
+		if now.After(key.sidelinedUntil) {
+			r.next = (r.next + i + 1) % len(r.keys)
+			return key, nil
+		}
+	}
+
+	err := fmt.Errorf("all %d Steam API keys are cooling down after being rejected", len(r.keys))
+	return nil, apperrors.WrapAPIError(429, err, "no Steam API key available")
+}
+
+func (r *keyRing) sideline(key *apiKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key.sidelinedUntil = time.Now().Add(r.cooldown)
+}
+
+// sidelineUnlessLast sidelines key if another key is still usable, and
+// reports whether it did. The last usable key is kept so the caller can wait
+// and retry with it instead of failing outright.
+func (r *keyRing) sidelineUnlessLast(key *apiKey) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, other := range r.keys {
+		if other != key && now.After(other.sidelinedUntil) {
+			key.sidelinedUntil = now.Add(r.cooldown)
+			return true
+		}
+	}
+	return false
+}
+
+type keyTrackerKey struct{}
+
+type keyTracker struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+// WithKeyTracking returns a context that remembers which API keys served the
+// Steam calls made with it; read them back with UsedKeys.
+func WithKeyTracking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, keyTrackerKey{}, &keyTracker{keys: make(map[string]struct{})})
+}
+
+// UsedKeys returns the KeyIDs of the keys used under ctx, comma-separated
+// and sorted, or "" when no call reached Steam.
+func UsedKeys(ctx context.Context) string {
+	tracker, ok := ctx.Value(keyTrackerKey{}).(*keyTracker)
+	if !ok {
+		return ""
+	}
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	ids := make([]string, 0, len(tracker.keys))
+	for id := range tracker.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+func trackKey(ctx context.Context, key *apiKey) {
+	tracker, ok := ctx.Value(keyTrackerKey{}).(*keyTracker)
+	if !ok {
+		return
+	}
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	tracker.keys[key.id()] = struct{}{}
+}
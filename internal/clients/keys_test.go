@@ -0,0 +1,139 @@
+package clients_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSteamClientFailsOverRejectedKeys(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		seen = append(seen, key)
+		if key == "revoked-key-0001" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"game": {}}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewSteamClient([]string{"revoked-key-0001", "healthy-key-0002"}, server.URL, server.Client(), clients.Options{
+		KeyCooldown: time.Hour,
+	})
+
+	ctx := clients.WithKeyTracking(context.Background())
+	_, err := client.GetSchemaForGame(ctx, "440")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{clients.KeyID("revoked-key-0001"), clients.KeyID("healthy-key-0002")}, strings.Split(clients.UsedKeys(ctx), ","))
+
+	// The rejected key stays out of rotation during its cooldown
+	for i := 0; i < 3; i++ {
+		_, err := client.GetSchemaForGame(context.Background(), "440")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"revoked-key-0001", "healthy-key-0002", "healthy-key-0002", "healthy-key-0002", "healthy-key-0002"}, seen)
+}
+
+func TestSteamClientRotatesKeys(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.URL.Query().Get("key"))
+		w.Write([]byte(`{"game": {}}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewSteamClient([]string{"a", "b", "c"}, server.URL, server.Client(), clients.Options{})
+	for i := 0; i < 4; i++ {
+		_, err := client.GetSchemaForGame(context.Background(), "440")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "a"}, seen)
+}
+
+func TestSteamClientKeepsKeysOnPrivateProfile(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.URL.Query().Get("key"))
+		if r.URL.Path == "/ISteamUserStats/GetPlayerAchievements/v0001/" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"playerstats": {"error": "Profile is not public", "success": false}}`))
+			return
+		}
+		w.Write([]byte(`{"game": {}}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewSteamClient([]string{"a", "b", "c"}, server.URL, server.Client(), clients.Options{
+		KeyCooldown: time.Hour,
+	})
+
+	_, err := client.GetPlayerAchievements(context.Background(), "76561197960287930", "440")
+	var apiErr *apperrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+
+	// No failover, and every key is still in rotation
+	for i := 0; i < 3; i++ {
+		_, err := client.GetSchemaForGame(context.Background(), "440")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, seen)
+}
+
+func TestSteamClientSidelinesInvalidKeyOnDataEndpoint(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		seen = append(seen, key)
+		if key == "revoked-key-0001" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<html><head><title>Forbidden</title></head><body><h1>Forbidden</h1>Access is denied. Retrying will not help. Please verify your <pre>key=</pre> parameter.</body></html>`))
+			return
+		}
+		w.Write([]byte(`{"playerstats": {"success": true}}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewSteamClient([]string{"revoked-key-0001", "healthy-key-0002"}, server.URL, server.Client(), clients.Options{
+		KeyCooldown: time.Hour,
+	})
+
+	_, err := client.GetPlayerAchievements(context.Background(), "76561197960287930", "440")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"revoked-key-0001", "healthy-key-0002"}, seen)
+}
+
+func TestSteamClientFailsOverRateLimitedKey(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		seen = append(seen, key)
+		if key == "a" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"game": {}}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewSteamClient([]string{"a", "b"}, server.URL, server.Client(), clients.Options{
+		KeyCooldown: time.Hour,
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetSchemaForGame(context.Background(), "440")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"a", "b", "b"}, seen)
+}
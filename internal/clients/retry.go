@@ -0,0 +1,52 @@
+package clients
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how transient failures (transport errors, 5xx and
+// 429 responses) are retried. MaxAttempts counts the first call, so 1 or
+// less disables retries.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// backoff returns how long to wait before retry number attempt (starting at
+// 1), using full jitter over an exponentially growing window. A Retry-After
+// hint from Steam takes precedence; ok is false when that hint exceeds
+// MaxDelay and the caller should give up instead of holding the request.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) (delay time.Duration, ok bool) {
+	if retryAfter > 0 {
+		return retryAfter, p.MaxDelay <= 0 || retryAfter <= p.MaxDelay
+	}
+
+	window := p.BaseDelay << (attempt - 1)
+	if window <= 0 || (p.MaxDelay > 0 && window > p.MaxDelay) {
+		window = p.MaxDelay
+	}
+	if window <= 0 {
+		return 0, true
+	}
+	return rand.N(window), true
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date. It returns 0 when the header is absent or malformed.
+func parseRetryAfter(header http.Header) time.Duration {
+	val := header.Get("Retry-After")
+	if val == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(val); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(val); err == nil {
+		return max(time.Until(when), 0)
+	}
+	return 0
+}
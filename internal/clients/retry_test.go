@@ -0,0 +1,113 @@
+package clients_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyServer fails the first failures calls with status, then succeeds.
+func flakyServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"game": {"gameName": "Team Fortress 2"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestSteamClientRetriesTransientFailures(t *testing.T) {
+	server, calls := flakyServer(t, 2, http.StatusServiceUnavailable, nil)
+	client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{
+		Retry: clients.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+	})
+
+	result, err := client.GetSchemaForGame(context.Background(), "440")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Team Fortress 2", result.Game.GameName)
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestSteamClientDoesNotRetryClientErrors(t *testing.T) {
+	server, calls := flakyServer(t, 1, http.StatusForbidden, nil)
+	client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{
+		Retry: clients.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	})
+
+	_, err := client.GetSchemaForGame(context.Background(), "440")
+
+	require.Error(t, err)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestSteamClientGivesUpOnLongRetryAfter(t *testing.T) {
+	server, calls := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}})
+	client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{
+		Retry: clients.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+	})
+
+	_, err := client.GetSchemaForGame(context.Background(), "440")
+
+	var apiErr *apperrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestSteamClientRetriesRateLimitedLastKey(t *testing.T) {
+	server, calls := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+	client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{
+		Retry:       clients.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Second},
+		KeyCooldown: time.Hour,
+	})
+
+	_, err := client.GetSchemaForGame(context.Background(), "440")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load())
+
+	// The only key was never sidelined
+	_, err = client.GetSchemaForGame(context.Background(), "440")
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestSteamClientCircuitBreaker(t *testing.T) {
+	server, calls := flakyServer(t, 2, http.StatusBadGateway, nil)
+	breakers := clients.NewBreakers(2, 20*time.Millisecond)
+	client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{Breakers: breakers})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetSchemaForGame(context.Background(), "440")
+		require.Error(t, err)
+	}
+	assert.Equal(t, "open", breakers.Status()["GetSchemaForGame"].State)
+
+	// Open breaker short-circuits without reaching Steam
+	_, err := client.GetSchemaForGame(context.Background(), "440")
+	var apiErr *apperrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.EqualValues(t, 2, calls.Load())
+
+	// After the cooldown a trial call goes through and closes the breaker
+	time.Sleep(25 * time.Millisecond)
+	_, err = client.GetSchemaForGame(context.Background(), "440")
+	require.NoError(t, err)
+	assert.Equal(t, "closed", breakers.Status()["GetSchemaForGame"].State)
+}
@@ -0,0 +1,359 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"golang.org/x/time/rate"
+)
+
+const DefaultSteamAPIBaseURL = "https://api.steampowered.com"
+
+const (
+	resolveVanityURLPath             = "/ISteamUser/ResolveVanityURL/v0001/"
+	playerSummariesPath              = "/ISteamUser/GetPlayerSummaries/v0002/"
+	playerBansPath                   = "/ISteamUser/GetPlayerBans/v1/"
+	friendListPath                   = "/ISteamUser/GetFriendList/v0001/"
+	ownedGamesPath                   = "/IPlayerService/GetOwnedGames/v1/"
+	steamLevelPath                   = "/IPlayerService/GetSteamLevel/v1/"
+	badgesPath                       = "/IPlayerService/GetBadges/v1/"
+	recentlyPlayedGamesPath          = "/IPlayerService/GetRecentlyPlayedGames/v1/"
+	gameSchemaPath                   = "/ISteamUserStats/GetSchemaForGame/v2/"
+	numberOfCurrentPlayersPath       = "/ISteamUserStats/GetNumberOfCurrentPlayers/v1/"
+	newsForAppPath                   = "/ISteamNews/GetNewsForApp/v0002/"
+	userStatsForGamePath             = "/ISteamUserStats/GetUserStatsForGame/v0002/"
+	playerAchievementsPath           = "/ISteamUserStats/GetPlayerAchievements/v0001/"
+	globalAchievementPercentagesPath = "/ISteamUserStats/GetGlobalAchievementPercentagesForApp/v0002/"
+)
+
+// SteamClient talks to the Steam Web API. Every method maps to exactly one
+// upstream endpoint and returns the decoded response as-is; interpreting the
+// payload (e.g. "success" flags) is left to the service layer.
+type SteamClient interface {
+	ResolveVanityURL(ctx context.Context, vanityName string) (*models.ResolveVanityURLResponse, error)
+	// GetPlayerSummaries accepts up to 100 steamIDs per call.
+	GetPlayerSummaries(ctx context.Context, steamIDs []string) (*models.Summary, error)
+	// GetPlayerBans accepts up to 100 steamIDs per call.
+	GetPlayerBans(ctx context.Context, steamIDs []string) (*models.PlayerBansResponse, error)
+	GetFriendList(ctx context.Context, steamID string) (*models.FriendListResponse, error)
+	GetSteamLevel(ctx context.Context, steamID string) (*models.SteamLevelResponse, error)
+	GetBadges(ctx context.Context, steamID string) (*models.BadgesResponse, error)
+	GetOwnedGames(ctx context.Context, steamID string) (*models.OwnedGamesResponse, error)
+	GetRecentlyPlayedGames(ctx context.Context, steamID string) (*models.RecentlyPlayedGamesResponse, error)
+	GetSchemaForGame(ctx context.Context, appID string) (*models.GameSchemaResponse, error)
+	GetPlayerAchievements(ctx context.Context, steamID, appID string) (*models.PlayerAchievementsResponse, error)
+	GetGlobalAchievementPercentages(ctx context.Context, appID string) (*models.GlobalAchievementPercentagesResponse, error)
+	GetUserStatsForGame(ctx context.Context, steamID, appID string) (*models.UserStatsForGameResponse, error)
+	GetNewsForApp(ctx context.Context, appID string, filter models.NewsFilter) (*models.NewsForAppResponse, error)
+	GetNumberOfCurrentPlayers(ctx context.Context, appID string) (*models.NumberOfCurrentPlayersResponse, error)
+}
+
+// QuotaTracker counts outbound calls against each API key's daily budget.
+type QuotaTracker interface {
+	// Reserve records one call to endpoint with the key identified by keyID, or
+	// fails once that key's budget is spent.
+	Reserve(ctx context.Context, keyID, endpoint string) error
+}
+
+// Options configures the behaviour shared by every outbound call.
+type Options struct {
+	// Limiter throttles outbound calls; nil means unlimited.
+	Limiter *rate.Limiter
+	// Quota is charged for every call that passes the limiter; nil disables it.
+	Quota QuotaTracker
+	// Retry controls retries of transient failures; the zero value disables them.
+	Retry RetryPolicy
+	// Breakers short-circuits endpoints that keep failing; nil disables them.
+	Breakers *Breakers
+	// KeyCooldown is how long a key Steam rejects as invalid or rate limits
+	// is taken out of rotation.
+	KeyCooldown time.Duration
+}
+
+type steamClient struct {
+	// keys is nil for APIs that don't take a key, such as the store.
+	keys       *keyRing
+	baseURL    string
+	httpClient *http.Client
+	options    Options
+}
+
+// NewSteamClient returns a client that rotates through apiKeys round-robin,
+// failing over to the next key when Steam rejects one.
+func NewSteamClient(apiKeys []string, baseURL string, httpClient *http.Client, options Options) SteamClient {
+	if baseURL == "" {
+		baseURL = DefaultSteamAPIBaseURL
+	}
+	return &steamClient{
+		keys:       newKeyRing(apiKeys, options.KeyCooldown),
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		options:    options,
+	}
+}
+
+func (c *steamClient) ResolveVanityURL(ctx context.Context, vanityName string) (*models.ResolveVanityURLResponse, error) {
+	query := url.Values{"vanityurl": {vanityName}}
+	return getJSON[models.ResolveVanityURLResponse](ctx, c, "ResolveVanityURL", resolveVanityURLPath, query)
+}
+
+func (c *steamClient) GetPlayerSummaries(ctx context.Context, steamIDs []string) (*models.Summary, error) {
+	query := url.Values{"steamids": {strings.Join(steamIDs, ",")}}
+	return getJSON[models.Summary](ctx, c, "GetPlayerSummaries", playerSummariesPath, query)
+}
+
+func (c *steamClient) GetPlayerBans(ctx context.Context, steamIDs []string) (*models.PlayerBansResponse, error) {
+	query := url.Values{"steamids": {strings.Join(steamIDs, ",")}}
+	return getJSON[models.PlayerBansResponse](ctx, c, "GetPlayerBans", playerBansPath, query)
+}
+
+func (c *steamClient) GetFriendList(ctx context.Context, steamID string) (*models.FriendListResponse, error) {
+	query := url.Values{"steamid": {steamID}, "relationship": {"friend"}}
+	return getJSON[models.FriendListResponse](ctx, c, "GetFriendList", friendListPath, query)
+}
+
+func (c *steamClient) GetSteamLevel(ctx context.Context, steamID string) (*models.SteamLevelResponse, error) {
+	query := url.Values{"steamid": {steamID}}
+	return getJSON[models.SteamLevelResponse](ctx, c, "GetSteamLevel", steamLevelPath, query)
+}
+
+func (c *steamClient) GetBadges(ctx context.Context, steamID string) (*models.BadgesResponse, error) {
+	query := url.Values{"steamid": {steamID}}
+	return getJSON[models.BadgesResponse](ctx, c, "GetBadges", badgesPath, query)
+}
+
+func (c *steamClient) GetOwnedGames(ctx context.Context, steamID string) (*models.OwnedGamesResponse, error) {
+	query := url.Values{"steamid": {steamID}, "include_appinfo": {"true"}}
+	return getJSON[models.OwnedGamesResponse](ctx, c, "GetOwnedGames", ownedGamesPath, query)
+}
+
+func (c *steamClient) GetRecentlyPlayedGames(ctx context.Context, steamID string) (*models.RecentlyPlayedGamesResponse, error) {
+	query := url.Values{"steamid": {steamID}}
+	return getJSON[models.RecentlyPlayedGamesResponse](ctx, c, "GetRecentlyPlayedGames", recentlyPlayedGamesPath, query)
+}
+
+func (c *steamClient) GetSchemaForGame(ctx context.Context, appID string) (*models.GameSchemaResponse, error) {
+	query := url.Values{"appid": {appID}}
+	return getJSON[models.GameSchemaResponse](ctx, c, "GetSchemaForGame", gameSchemaPath, query)
+}
+
+func (c *steamClient) GetPlayerAchievements(ctx context.Context, steamID, appID string) (*models.PlayerAchievementsResponse, error) {
+	query := url.Values{"steamid": {steamID}, "appid": {appID}}
+	return getJSON[models.PlayerAchievementsResponse](ctx, c, "GetPlayerAchievements", playerAchievementsPath, query)
+}
+
+func (c *steamClient) GetGlobalAchievementPercentages(ctx context.Context, appID string) (*models.GlobalAchievementPercentagesResponse, error) {
+	query := url.Values{"gameid": {appID}}
+	return getJSON[models.GlobalAchievementPercentagesResponse](ctx, c, "GetGlobalAchievementPercentages", globalAchievementPercentagesPath, query)
+}
+
+func (c *steamClient) GetUserStatsForGame(ctx context.Context, steamID, appID string) (*models.UserStatsForGameResponse, error) {
+	query := url.Values{"steamid": {steamID}, "appid": {appID}}
+	return getJSON[models.UserStatsForGameResponse](ctx, c, "GetUserStatsForGame", userStatsForGamePath, query)
+}
+
+func (c *steamClient) GetNewsForApp(ctx context.Context, appID string, filter models.NewsFilter) (*models.NewsForAppResponse, error) {
+	query := url.Values{"appid": {appID}}
+	if filter.Count > 0 {
+		query.Set("count", strconv.Itoa(filter.Count))
+	}
+	if filter.MaxLength > 0 {
+		query.Set("maxlength", strconv.Itoa(filter.MaxLength))
+	}
+	if len(filter.Feeds) > 0 {
+		query.Set("feeds", strings.Join(filter.Feeds, ","))
+	}
+	return getJSON[models.NewsForAppResponse](ctx, c, "GetNewsForApp", newsForAppPath, query)
+}
+
+func (c *steamClient) GetNumberOfCurrentPlayers(ctx context.Context, appID string) (*models.NumberOfCurrentPlayersResponse, error) {
+	query := url.Values{"appid": {appID}}
+	return getJSON[models.NumberOfCurrentPlayersResponse](ctx, c, "GetNumberOfCurrentPlayers", numberOfCurrentPlayersPath, query)
+}
+
+// getJSON performs a GET against the Steam Web API and decodes the JSON body
+// into T. Every failure is returned as an *apperrors.APIError: transport and
+// decoding problems map to 500, non-200 responses keep Steam's status code,
+// calls refused by the rate limiter or by the daily quota of every key map
+// to 429 and calls rejected by an open circuit breaker map to 503.
+//
+// Transient failures are retried according to Options.Retry; each retry is
+// a new outbound call and passes the limiter and quota again. A key Steam
+// rejects as invalid is sidelined, as is a rate limited key while another
+// key is usable, and the call is repeated straight away with the next key
+// without using up a retry; so is a key whose daily quota is spent. A 429
+// on the last usable key is left to the
+// retry policy, and a 403 that is about the requested data, such as a
+// private profile, is returned as-is without touching the key.
+func getJSON[T any](ctx context.Context, c *steamClient, op, path string, query url.Values) (*T, error) {
+	var result T
+	attempt, failovers := 1, 0
+	for {
+		if !c.options.Breakers.allow(op) {
+			err := fmt.Errorf("too many recent failures calling %s", op)
+			return nil, apperrors.WrapAPIError(503, err, op+" circuit open")
+		}
+
+		var key *apiKey
+		if c.keys != nil {
+			var err error
+			if key, err = c.keys.pick(); err != nil {
+				c.options.Breakers.release(op)
+				return nil, err
+			}
+		}
+
+		res := c.attempt(ctx, op, path, query, key, &result)
+		if res.sent {
+			c.options.Breakers.record(op, !res.transient)
+		} else {
+			c.options.Breakers.release(op)
+		}
+		if res.err == nil {
+			return &result, nil
+		}
+
+		canFailOver := key != nil && failovers < c.keys.size()-1
+		if res.invalidKey {
+			log.Printf("steam rejected API key %s as invalid, sidelining it", MaskKey(key.value))
+			c.keys.sideline(key)
+			if canFailOver {
+				failovers++
+				continue
+			}
+		} else if res.keyExhausted && canFailOver {
+			// Not sidelined: pick moves on to the next key, and the quota
+			// keeps refusing this one until the day is over
+			failovers++
+			continue
+		} else if res.status == http.StatusTooManyRequests && canFailOver && c.keys.sidelineUnlessLast(key) {
+			log.Printf("steam rate limited API key %s, sidelining it", MaskKey(key.value))
+			failovers++
+			continue
+		}
+
+		if !res.transient || attempt >= c.options.Retry.MaxAttempts {
+			return nil, res.err
+		}
+
+		delay, ok := c.options.Retry.backoff(attempt, res.retryAfter)
+		if !ok {
+			return nil, res.err
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, res.err
+		}
+		attempt++
+	}
+}
+
+type attemptResult struct {
+	err error
+	// sent is false when the call never left the service (limiter, quota).
+	sent bool
+	// status is Steam's HTTP status, or 0 if no response was received.
+	status int
+	// transient marks failures worth retrying and counting against the breaker.
+	transient  bool
+	retryAfter time.Duration
+	// invalidKey is set when Steam refused the API key itself.
+	invalidKey bool
+	// keyExhausted is set when the key's daily budget is spent.
+	keyExhausted bool
+}
+
+// dataForbiddenOps are the endpoints that answer 403 for data the key may not
+// see, such as a private profile's achievements. There a 403 only means a
+// bad key when its body says so.
+var dataForbiddenOps = map[string]bool{
+	"GetPlayerAchievements": true,
+	"GetUserStatsForGame":   true,
+}
+
+// rejectsKey reports whether body is Steam's response to an invalid key, an
+// HTML page asking to "verify your <pre>key=</pre> parameter".
+func rejectsKey(body []byte) bool {
+	return bytes.Contains(body, []byte("key=")) && bytes.Contains(bytes.ToLower(body), []byte("verify your"))
+}
+
+// withoutURL drops the request URL, which carries the API key, from the
+// errors net/http reports.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
+
+func (c *steamClient) attempt(ctx context.Context, op, path string, query url.Values, key *apiKey, out any) attemptResult {
+	if c.options.Limiter != nil {
+		if err := c.options.Limiter.Wait(ctx); err != nil {
+			return attemptResult{err: apperrors.WrapAPIError(429, err, op+" rate limited")}
+		}
+	}
+	if c.options.Quota != nil && key != nil {
+		if err := c.options.Quota.Reserve(ctx, key.id(), op); err != nil {
+			return attemptResult{err: err, keyExhausted: true}
+		}
+	}
+
+	if key != nil {
+		query.Set("key", key.value)
+		trackKey(ctx, key)
+	}
+	endpoint := c.baseURL + path + "?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return attemptResult{err: apperrors.WrapAPIError(500, withoutURL(err), op+" request creation failed")}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// A call abandoned by our own caller says nothing about Steam's health
+		aborted := ctx.Err() != nil
+		return attemptResult{
+			err:       apperrors.WrapAPIError(500, withoutURL(err), op+" request failed"),
+			sent:      !aborted,
+			transient: !aborted,
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("steam API responded with status: %s", resp.Status)
+		result := attemptResult{
+			err:        apperrors.WrapAPIError(resp.StatusCode, err, op+" returned non-200"),
+			sent:       true,
+			status:     resp.StatusCode,
+			transient:  resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests,
+			retryAfter: parseRetryAfter(resp.Header),
+		}
+		if key != nil && resp.StatusCode == http.StatusForbidden {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			result.invalidKey = rejectsKey(body) || !dataForbiddenOps[op]
+		}
+		return result
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return attemptResult{err: apperrors.WrapAPIError(500, err, op+" JSON decode failed"), sent: true, status: resp.StatusCode}
+	}
+
+	return attemptResult{sent: true, status: resp.StatusCode}
+}
@@ -0,0 +1,184 @@
+package clients_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestSteamClientUsesBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ISteamUser/ResolveVanityURL/v0001/", r.URL.Path)
+		assert.Equal(t, "test_key", r.URL.Query().Get("key"))
+		assert.Equal(t, "gabelogannewell", r.URL.Query().Get("vanityurl"))
+		w.Write([]byte(`{"response": {"steamid": "76561197960287930", "success": 1}}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{})
+	result, err := client.ResolveVanityURL(context.Background(), "gabelogannewell")
+
+	require.NoError(t, err)
+	assert.Equal(t, "76561197960287930", result.Response.SteamID)
+	assert.Equal(t, 1, result.Response.Success)
+}
+
+func TestSteamClientDecodesRecentlyPlayedGames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/IPlayerService/GetRecentlyPlayedGames/v1/", r.URL.Path)
+		assert.Equal(t, "76561197960287930", r.URL.Query().Get("steamid"))
+		w.Write([]byte(`{"response": {"total_count": 1, "games": [{
+			"appid": 570, "name": "Dota 2", "playtime_2weeks": 95, "playtime_forever": 12000,
+			"playtime_windows_forever": 11000, "playtime_linux_forever": 1000, "rtime_last_played": 1700000000
+		}]}}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{})
+	result, err := client.GetRecentlyPlayedGames(context.Background(), "76561197960287930")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Response.TotalCount)
+	require.Len(t, result.Response.Games, 1)
+	game := result.Response.Games[0]
+	assert.Equal(t, 95, game.Playtime2Weeks)
+	assert.Equal(t, 12000, game.PlaytimeForever)
+	assert.Equal(t, 1000, game.PlaytimeLinuxForever)
+	assert.Equal(t, int64(1700000000), game.RTimeLastPlayed)
+}
+
+func TestSteamClientMapsErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantStatus int
+	}{
+		{name: "upstream status is kept", status: http.StatusForbidden, body: "forbidden", wantStatus: http.StatusForbidden},
+		{name: "bad JSON is a 500", status: http.StatusOK, body: "<html>", wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{})
+			_, err := client.GetOwnedGames(context.Background(), "76561197960287930")
+
+			var apiErr *apperrors.APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.wantStatus, apiErr.StatusCode)
+		})
+	}
+}
+
+func TestSteamClientErrorsHideKey(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := clients.NewSteamClient([]string{"SUPERSECRETKEY1234"}, server.URL, server.Client(), clients.Options{})
+	_, err := client.GetOwnedGames(context.Background(), "76561197960287930")
+
+	var apiErr *apperrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.NotContains(t, err.Error(), "SUPERSECRETKEY1234")
+}
+
+// budgetQuota allows remaining calls per key
+type budgetQuota struct {
+	remaining map[string]int
+	calls     []string
+}
+
+func (q *budgetQuota) Reserve(ctx context.Context, keyID, endpoint string) error {
+	if q.remaining[keyID] == 0 {
+		return apperrors.NewAPIError(http.StatusTooManyRequests, "budget exhausted")
+	}
+	q.remaining[keyID]--
+	q.calls = append(q.calls, keyID+" "+endpoint)
+	return nil
+}
+
+func TestSteamClientChargesQuota(t *testing.T) {
+	var upstreamCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamCalls++
+		w.Write([]byte(`{"game": {}}`))
+	}))
+	defer server.Close()
+
+	quota := &budgetQuota{remaining: map[string]int{clients.KeyID("test_key"): 1}}
+	client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{Quota: quota})
+
+	_, err := client.GetSchemaForGame(context.Background(), "440")
+	require.NoError(t, err)
+
+	_, err = client.GetSchemaForGame(context.Background(), "440")
+	var apiErr *apperrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+
+	assert.Equal(t, 1, upstreamCalls)
+	assert.Equal(t, []string{clients.KeyID("test_key") + " GetSchemaForGame"}, quota.calls)
+}
+
+func TestSteamClientChargesQuotaPerKey(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.URL.Query().Get("key"))
+		w.Write([]byte(`{"game": {}}`))
+	}))
+	defer server.Close()
+
+	// The first key has spent its budget; calls move on to the second, which
+	// is counted apart even though both end in the same characters
+	quota := &budgetQuota{remaining: map[string]int{clients.KeyID("spent-key-0001"): 0, clients.KeyID("fresh-key-0001"): 2}}
+	client := clients.NewSteamClient([]string{"spent-key-0001", "fresh-key-0001"}, server.URL, server.Client(), clients.Options{Quota: quota})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetSchemaForGame(context.Background(), "440")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"fresh-key-0001", "fresh-key-0001"}, seen)
+
+	_, err := client.GetSchemaForGame(context.Background(), "440")
+	var apiErr *apperrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+}
+
+func TestSteamClientRateLimiterHonoursContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"game": {}}`))
+	}))
+	defer server.Close()
+
+	// One token per hour: the second call can't be served before the deadline
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	client := clients.NewSteamClient([]string{"test_key"}, server.URL, server.Client(), clients.Options{Limiter: limiter})
+
+	_, err := client.GetSchemaForGame(context.Background(), "440")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.GetSchemaForGame(ctx, "440")
+
+	var apiErr *apperrors.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+}
@@ -0,0 +1,49 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+)
+
+const (
+	DefaultSteamStoreBaseURL = "https://store.steampowered.com"
+
+	appDetailsPath = "/api/appdetails"
+)
+
+// StoreClient talks to the Steam store's public API.
+type StoreClient interface {
+	// GetAppDetails returns an app's store page localized for countryCode
+	// and language; empty values let the store pick.
+	GetAppDetails(ctx context.Context, appID, countryCode, language string) (*models.AppDetailsResponse, error)
+}
+
+// NewStoreClient returns a client for the store API. The store takes no API
+// key and isn't counted against the Web API budget, so options.Quota and
+// options.KeyCooldown are ignored.
+func NewStoreClient(baseURL string, httpClient *http.Client, options Options) StoreClient {
+	if baseURL == "" {
+		baseURL = DefaultSteamStoreBaseURL
+	}
+	options.Quota = nil
+	return &steamClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		options:    options,
+	}
+}
+
+func (c *steamClient) GetAppDetails(ctx context.Context, appID, countryCode, language string) (*models.AppDetailsResponse, error) {
+	query := url.Values{"appids": {appID}}
+	if countryCode != "" {
+		query.Set("cc", countryCode)
+	}
+	if language != "" {
+		query.Set("l", language)
+	}
+	return getJSON[models.AppDetailsResponse](ctx, c, "GetAppDetails", appDetailsPath, query)
+}
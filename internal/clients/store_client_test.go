@@ -0,0 +1,39 @@
+package clients_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreClientGetAppDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/appdetails", r.URL.Path)
+		assert.Equal(t, "570", r.URL.Query().Get("appids"))
+		assert.Equal(t, "de", r.URL.Query().Get("cc"))
+		assert.Equal(t, "german", r.URL.Query().Get("l"))
+		// The store is not keyed; Web API keys must not leak to it
+		assert.False(t, r.URL.Query().Has("key"))
+		w.Write([]byte(`{"570": {"success": true, "data": {
+			"type": "game", "name": "Dota 2", "steam_appid": 570, "is_free": true,
+			"developers": ["Valve"], "genres": [{"id": "1", "description": "Action"}],
+			"platforms": {"windows": true, "mac": true, "linux": true}
+		}}}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewStoreClient(server.URL, server.Client(), clients.Options{})
+	result, err := client.GetAppDetails(context.Background(), "570", "de", "german")
+
+	require.NoError(t, err)
+	details := (*result)["570"]
+	require.True(t, details.Success)
+	assert.Equal(t, "Dota 2", details.Data.Name)
+	assert.Equal(t, []string{"Valve"}, details.Data.Developers)
+	assert.True(t, details.Data.Platforms.Linux)
+}
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS api_quota (
+    day DATE NOT NULL,
+    endpoint TEXT NOT NULL,
+    calls INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, endpoint)
+);
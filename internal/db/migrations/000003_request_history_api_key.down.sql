@@ -0,0 +1 @@
+ALTER TABLE request_history DROP COLUMN IF EXISTS api_key;
\ No newline at end of file
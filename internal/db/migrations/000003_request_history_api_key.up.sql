@@ -0,0 +1 @@
+ALTER TABLE request_history ADD COLUMN IF NOT EXISTS api_key TEXT;
\ No newline at end of file
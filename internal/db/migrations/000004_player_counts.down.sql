@@ -0,0 +1 @@
+DROP TABLE IF EXISTS player_counts;
\ No newline at end of file
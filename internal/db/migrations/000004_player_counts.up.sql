@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS player_counts (
+    app_id INTEGER NOT NULL,
+    sampled_at TIMESTAMPTZ NOT NULL,
+    players INTEGER NOT NULL,
+    PRIMARY KEY (app_id, sampled_at)
+);
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS api_quota_daily (
+    day DATE PRIMARY KEY,
+    calls INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO api_quota_daily (day, calls)
+SELECT day, SUM(calls) FROM api_quota GROUP BY day
+ON CONFLICT (day) DO NOTHING;
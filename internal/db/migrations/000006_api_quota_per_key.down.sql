@@ -0,0 +1,13 @@
+CREATE TABLE api_quota_daily_merged AS
+SELECT day, SUM(calls)::INTEGER AS calls FROM api_quota_daily GROUP BY day;
+DROP TABLE api_quota_daily;
+ALTER TABLE api_quota_daily_merged RENAME TO api_quota_daily;
+ALTER TABLE api_quota_daily ALTER COLUMN calls SET NOT NULL, ALTER COLUMN calls SET DEFAULT 0;
+ALTER TABLE api_quota_daily ADD PRIMARY KEY (day);
+
+CREATE TABLE api_quota_merged AS
+SELECT day, endpoint, SUM(calls)::INTEGER AS calls FROM api_quota GROUP BY day, endpoint;
+DROP TABLE api_quota;
+ALTER TABLE api_quota_merged RENAME TO api_quota;
+ALTER TABLE api_quota ALTER COLUMN calls SET NOT NULL, ALTER COLUMN calls SET DEFAULT 0;
+ALTER TABLE api_quota ADD PRIMARY KEY (day, endpoint);
@@ -0,0 +1,7 @@
+ALTER TABLE api_quota_daily ADD COLUMN IF NOT EXISTS api_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE api_quota_daily DROP CONSTRAINT IF EXISTS api_quota_daily_pkey;
+ALTER TABLE api_quota_daily ADD PRIMARY KEY (day, api_key);
+
+ALTER TABLE api_quota ADD COLUMN IF NOT EXISTS api_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE api_quota DROP CONSTRAINT IF EXISTS api_quota_pkey;
+ALTER TABLE api_quota ADD PRIMARY KEY (day, api_key, endpoint);
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/Uranury/RBK_fetchAPI/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	quotaService *services.QuotaService
+}
+
+func NewAdminHandler(quotaService *services.QuotaService) *AdminHandler {
+	return &AdminHandler{quotaService: quotaService}
+}
+
+// GetQuota godoc
+// @Summary      returns today's Steam API usage of each key against the daily per-key budget
+// @Tags         admin
+// @Produce      json
+// @Success      200 {object} models.QuotaUsage
+// @Failure      500 {object} apperrors.APIError
+// @Router       /admin/quota [get]
+func (h *AdminHandler) GetQuota(c *gin.Context) {
+	usage, err := h.quotaService.GetUsage(c.Request.Context())
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, usage)
+}
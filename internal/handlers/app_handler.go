@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/Uranury/RBK_fetchAPI/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// maxNewsCount caps how many news items one request may ask for.
+const maxNewsCount = 100
+
+// AppHandler serves per-game endpoints under /apps/{appID}.
+type AppHandler struct {
+	steamService       *services.SteamService
+	playerCountService *services.PlayerCountService
+}
+
+func NewAppHandler(steamService *services.SteamService, playerCountService *services.PlayerCountService) *AppHandler {
+	return &AppHandler{steamService: steamService, playerCountService: playerCountService}
+}
+
+// appID returns the appID path parameter, or responds with 400 and returns
+// false if it isn't a positive integer.
+func appID(c *gin.Context) (string, bool) {
+	id := c.Param("appID")
+	if n, err := strconv.Atoi(id); err != nil || n <= 0 {
+		c.JSON(400, gin.H{"error": "appID must be a positive integer"})
+		return "", false
+	}
+	return id, true
+}
+
+// queryInt returns the non-negative integer query parameter name, 0 when it
+// is missing, or responds with 400 and returns false if it is invalid or
+// above max.
+func queryInt(c *gin.Context, name string, max int) (int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 || n > max {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("%s must be an integer between 0 and %d", name, max)})
+		return 0, false
+	}
+	return n, true
+}
+
+// optionalQueryInt is queryInt for parameters where a missing value must be
+// told apart from 0; it returns nil when name is missing.
+func optionalQueryInt(c *gin.Context, name string, max int) (*int, bool) {
+	if c.Query(name) == "" {
+		return nil, true
+	}
+	n, ok := queryInt(c, name, max)
+	if !ok {
+		return nil, false
+	}
+	return &n, true
+}
+
+// GetAppDetails godoc
+// @Summary      returns a game's store page with localized pricing
+// @Tags         apps
+// @Produce      json
+// @Param        appID path int true "App ID"
+// @Param        cc query string false "Country code for prices, e.g. us"
+// @Param        l query string false "Language, e.g. english"
+// @Success      200 {object} models.App
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} apperrors.APIError
+// @Failure      500 {object} apperrors.APIError
+// @Router       /apps/{appID} [get]
+func (h *AppHandler) GetAppDetails(c *gin.Context) {
+	appID, ok := appID(c)
+	if !ok {
+		return
+	}
+
+	app, err := h.steamService.GetAppDetails(c.Request.Context(), appID, c.Query("cc"), c.Query("l"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, app)
+}
+
+// GetAppNews godoc
+// @Summary      returns a game's news feed
+// @Description  Returns JSON by default, or an RSS 2.0 or Atom feed when the Accept header asks for application/rss+xml or application/atom+xml.
+// @Tags         apps
+// @Produce      json
+// @Produce      application/rss+xml
+// @Produce      application/atom+xml
+// @Param        appID path int true "App ID"
+// @Param        count query int false "Number of items, up to 100 (Steam's default is 20)"
+// @Param        maxlength query int false "Truncate contents to this many characters; 0 means full contents"
+// @Param        feeds query string false "Comma-separated feed names, e.g. steam_community_announcements"
+// @Success      200 {object} models.AppNews
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} apperrors.APIError
+// @Router       /apps/{appID}/news [get]
+func (h *AppHandler) GetAppNews(c *gin.Context) {
+	appID, ok := appID(c)
+	if !ok {
+		return
+	}
+	count, ok := queryInt(c, "count", maxNewsCount)
+	if !ok {
+		return
+	}
+	maxLength, ok := queryInt(c, "maxlength", math.MaxInt32)
+	if !ok {
+		return
+	}
+
+	filter := models.NewsFilter{Count: count, MaxLength: maxLength}
+	if feeds := c.Query("feeds"); feeds != "" {
+		filter.Feeds = strings.Split(feeds, ",")
+	}
+
+	news, err := h.steamService.GetAppNews(c.Request.Context(), appID, filter)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	switch c.NegotiateFormat(binding.MIMEJSON, mimeRSS, mimeAtom) {
+	case mimeRSS:
+		renderXML(c, mimeRSS, newsRSS(news))
+	case mimeAtom:
+		renderXML(c, mimeAtom, newsAtom(news))
+	default:
+		c.JSON(200, news)
+	}
+}
+
+// GetCurrentPlayers godoc
+// @Summary      returns how many players are in a game right now
+// @Tags         apps
+// @Produce      json
+// @Param        appID path int true "App ID"
+// @Success      200 {object} models.CurrentPlayers
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} apperrors.APIError
+// @Failure      500 {object} apperrors.APIError
+// @Router       /apps/{appID}/players [get]
+func (h *AppHandler) GetCurrentPlayers(c *gin.Context) {
+	appID, ok := appID(c)
+	if !ok {
+		return
+	}
+
+	players, err := h.steamService.GetCurrentPlayers(c.Request.Context(), appID)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, players)
+}
+
+// GetPlayerHistory godoc
+// @Summary      returns a game's sampled player counts aggregated per bucket
+// @Description  Only apps listed in PLAYER_SAMPLER_APP_IDS are sampled. Buckets are aligned to the unix epoch and empty buckets are left out.
+// @Tags         apps
+// @Produce      json
+// @Param        appID path int true "App ID"
+// @Param        from query string false "Start time, RFC 3339 (default: 24h before to)"
+// @Param        to query string false "End time, RFC 3339 (default: now)"
+// @Param        bucket query string false "Bucket width as a Go duration, at least 1m (default: 1h)"
+// @Success      200 {object} models.PlayerCountHistory
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} apperrors.APIError
+// @Router       /apps/{appID}/players/history [get]
+func (h *AppHandler) GetPlayerHistory(c *gin.Context) {
+	id, ok := appID(c)
+	if !ok {
+		return
+	}
+	appID, _ := strconv.Atoi(id)
+
+	to := time.Now()
+	if value := c.Query("to"); value != "" {
+		var err error
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			c.JSON(400, gin.H{"error": "to must be an RFC 3339 time"})
+			return
+		}
+	}
+	from := to.Add(-24 * time.Hour)
+	if value := c.Query("from"); value != "" {
+		var err error
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			c.JSON(400, gin.H{"error": "from must be an RFC 3339 time"})
+			return
+		}
+	}
+	bucket := time.Hour
+	if value := c.Query("bucket"); value != "" {
+		var err error
+		if bucket, err = time.ParseDuration(value); err != nil {
+			c.JSON(400, gin.H{"error": "bucket must be a duration such as 15m or 1h"})
+			return
+		}
+	}
+
+	history, err := h.playerCountService.GetHistory(c.Request.Context(), appID, from, to, bucket)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, history)
+}
+
+// GetGlobalAchievements godoc
+// @Summary      returns every achievement of a game with its global unlock percentage
+// @Description  Achievements come in the game's own order unless sort=rarity is given; order=asc (default) lists the rarest first.
+// @Tags         apps
+// @Produce      json
+// @Param        appID path int true "App ID"
+// @Param        sort query string false "Sort key" Enums(rarity)
+// @Param        order query string false "Sort order" Enums(asc, desc)
+// @Success      200 {object} models.GameAchievements
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} apperrors.APIError
+// @Router       /apps/{appID}/achievements/global [get]
+func (h *AppHandler) GetGlobalAchievements(c *gin.Context) {
+	appID, ok := appID(c)
+	if !ok {
+		return
+	}
+
+	order := services.AchievementOrderSchema
+	switch c.Query("sort") {
+	case "":
+	case "rarity":
+		switch c.DefaultQuery("order", "asc") {
+		case "asc":
+			order = services.AchievementOrderRarest
+		case "desc":
+			order = services.AchievementOrderCommon
+		default:
+			c.JSON(400, gin.H{"error": "order must be asc or desc"})
+			return
+		}
+	default:
+		c.JSON(400, gin.H{"error": "sort must be rarity"})
+		return
+	}
+
+	achievements, err := h.steamService.GetGlobalAchievements(c.Request.Context(), appID, order)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, achievements)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	breakers *clients.Breakers
+}
+
+func NewHealthHandler(breakers *clients.Breakers) *HealthHandler {
+	return &HealthHandler{breakers: breakers}
+}
+
+// GetHealth godoc
+// @Summary      reports service health and the state of each Steam endpoint's circuit breaker
+// @Description  status is "degraded" while any breaker is not closed
+// @Tags         admin
+// @Produce      json
+// @Success      200 {object} models.Health
+// @Router       /health [get]
+func (h *HealthHandler) GetHealth(c *gin.Context) {
+	health := models.Health{Status: "ok", Breakers: h.breakers.Status()}
+	for _, breaker := range health.Breakers {
+		if breaker.State != "closed" {
+			health.Status = "degraded"
+			break
+		}
+	}
+
+	c.JSON(200, health)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mimeRSS  = "application/rss+xml"
+	mimeAtom = "application/atom+xml"
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	Author      string  `xml:"author,omitempty"`
+	Category    string  `xml:"category,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID       string        `xml:"id"`
+	Title    string        `xml:"title"`
+	Updated  string        `xml:"updated"`
+	Link     atomLink      `xml:"link"`
+	Author   *atomAuthor   `xml:"author,omitempty"`
+	Category *atomCategory `xml:"category,omitempty"`
+	Content  atomContent   `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+type atomContent struct {
+	// Steam news contents are HTML or BBCode fragments
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// renderXML writes v as an XML document with the given content type.
+func renderXML(c *gin.Context, contentType string, v any) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	c.Data(200, contentType+"; charset=utf-8", append([]byte(xml.Header), body...))
+}
+
+func newsPageURL(appID int) string {
+	return fmt.Sprintf("https://store.steampowered.com/news/app/%d", appID)
+}
+
+func newsRSS(news *models.AppNews) *rssFeed {
+	feed := &rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       fmt.Sprintf("Steam news for app %d", news.AppID),
+			Link:        newsPageURL(news.AppID),
+			Description: fmt.Sprintf("Latest news for Steam app %d", news.AppID),
+		},
+	}
+	for _, item := range news.Items {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        item.URL,
+			Description: item.Contents,
+			Author:      item.Author,
+			Category:    item.FeedLabel,
+			GUID:        rssGUID{Value: item.ID},
+			PubDate:     item.Date.Format(time.RFC1123Z),
+		})
+	}
+	return feed
+}
+
+func newsAtom(news *models.AppNews) *atomFeed {
+	// An Atom feed is as recent as its newest entry
+	var updated time.Time
+	for _, item := range news.Items {
+		if item.Date.After(updated) {
+			updated = item.Date
+		}
+	}
+
+	feed := &atomFeed{
+		ID:      newsPageURL(news.AppID),
+		Title:   fmt.Sprintf("Steam news for app %d", news.AppID),
+		Updated: updated.Format(time.RFC3339),
+		Link:    atomLink{Href: newsPageURL(news.AppID), Rel: "alternate"},
+		Author:  atomAuthor{Name: "Steam"},
+	}
+	for _, item := range news.Items {
+		entry := atomEntry{
+			ID:      fmt.Sprintf("tag:steampowered.com,2004:news/%d/%s", news.AppID, item.ID),
+			Title:   item.Title,
+			Updated: item.Date.Format(time.RFC3339),
+			Link:    atomLink{Href: item.URL, Rel: "alternate"},
+			Content: atomContent{Type: "html", Value: item.Contents},
+		}
+		if item.Author != "" {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+		if item.FeedName != "" {
+			entry.Category = &atomCategory{Term: item.FeedName, Label: item.FeedLabel}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
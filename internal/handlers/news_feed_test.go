@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNews = &models.AppNews{
+	AppID: 440,
+	Total: 1,
+	Items: []models.NewsItem{{
+		ID:        "5123",
+		Title:     "Team Fortress 2 Update Released",
+		URL:       "https://steamstore-a.akamaihd.net/news/externalpost/tf2_blog/5123",
+		Author:    "Valve",
+		Contents:  "<p>Fixed a <b>crash</b></p>",
+		FeedName:  "tf2_blog",
+		FeedLabel: "TF2 Blog",
+		Date:      time.Date(2024, 3, 14, 18, 0, 0, 0, time.UTC),
+	}},
+}
+
+func render(t *testing.T, contentType string, v any) (string, string) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	renderXML(c, contentType, v)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	return w.Header().Get("Content-Type"), w.Body.String()
+}
+
+func TestNewsRSS(t *testing.T) {
+	contentType, body := render(t, mimeRSS, newsRSS(testNews))
+
+	assert.Equal(t, "application/rss+xml; charset=utf-8", contentType)
+	assert.True(t, strings.HasPrefix(body, xml.Header))
+
+	var feed rssFeed
+	require.NoError(t, xml.Unmarshal([]byte(body), &feed))
+	assert.Equal(t, "2.0", feed.Version)
+	require.Len(t, feed.Channel.Items, 1)
+	item := feed.Channel.Items[0]
+	assert.Equal(t, "Thu, 14 Mar 2024 18:00:00 +0000", item.PubDate)
+	assert.Equal(t, "<p>Fixed a <b>crash</b></p>", item.Description)
+	assert.Equal(t, "5123", item.GUID.Value)
+	assert.False(t, item.GUID.IsPermaLink)
+}
+
+func TestNewsAtom(t *testing.T) {
+	contentType, body := render(t, mimeAtom, newsAtom(testNews))
+
+	assert.Equal(t, "application/atom+xml; charset=utf-8", contentType)
+	assert.Contains(t, body, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+
+	var feed atomFeed
+	require.NoError(t, xml.Unmarshal([]byte(body), &feed))
+	assert.Equal(t, "2024-03-14T18:00:00Z", feed.Updated)
+	require.Len(t, feed.Entries, 1)
+	entry := feed.Entries[0]
+	assert.Equal(t, "tag:steampowered.com,2004:news/440/5123", entry.ID)
+	assert.Equal(t, "html", entry.Content.Type)
+	assert.Equal(t, "tf2_blog", entry.Category.Term)
+}
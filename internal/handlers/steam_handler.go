@@ -1,7 +1,13 @@
 package handlers
 
 import (
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+
 	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
 	"github.com/Uranury/RBK_fetchAPI/internal/services"
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +21,10 @@ func NewUserHandler(steamService *services.SteamService) *UserHandler {
 }
 
 func (h *UserHandler) RespondWithError(c *gin.Context, err error) {
+	respondWithError(c, err)
+}
+
+func respondWithError(c *gin.Context, err error) {
 	if apiErr, ok := err.(*apperrors.APIError); ok {
 		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
 	} else {
@@ -22,6 +32,24 @@ func (h *UserHandler) RespondWithError(c *gin.Context, err error) {
 	}
 }
 
+// steamID resolves the steamID query parameter, which may be in any format
+// ResolveSteamID accepts. It writes the error response and returns false when
+// the parameter is missing or can't be resolved.
+func (h *UserHandler) steamID(c *gin.Context) (string, bool) {
+	input := c.Query("steamID")
+	if input == "" {
+		c.JSON(400, gin.H{"error": "steam_id is required"})
+		return "", false
+	}
+
+	steamID, err := h.steamService.ResolveSteamID(c.Request.Context(), input)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return "", false
+	}
+	return steamID, true
+}
+
 // GetSteamID godoc
 // @Summary      Retrieve steamID under vanityID if it exists
 // @Tags         steamProfile
@@ -48,23 +76,69 @@ func (h *UserHandler) GetVanityProfile(c *gin.Context) {
 	c.JSON(200, gin.H{"steamID": steamID})
 }
 
+// ConvertSteamID godoc
+// @Summary      returns every representation of a SteamID
+// @Description  Accepts a SteamID64, SteamID2, SteamID3, profile URL or vanity name of any account type, including groups. steamID2 is only set for individual accounts.
+// @Tags         steamProfile
+// @Produce      json
+// @Param        id query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name"
+// @Success      200 {object} models.SteamIDConversion
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} apperrors.APIError
+// @Failure      500 {object} apperrors.APIError
+// @Router       /steam_id/convert [get]
+func (h *UserHandler) ConvertSteamID(c *gin.Context) {
+	id := c.Query("id")
+	if id == "" {
+		c.JSON(400, gin.H{"error": "id is required"})
+		return
+	}
+
+	conversion, err := h.steamService.ConvertSteamID(c.Request.Context(), id)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, conversion)
+}
+
+// Bounds of the /games query parameters.
+const (
+	maxGamesLimit  = 1000
+	maxGamesOffset = 1 << 20
+	maxPlaytime    = math.MaxInt32
+)
+
 // GetOwnedGames godoc
 // @Summary 	 returns user's owned games
+// @Description  Filters, sorting and paging run over the cached library. game_count is the size of the whole library and total the number of games matching the filters. Without limit every match is returned. Playtimes are in minutes.
 // @Tags 	 	 gamesInfo
 // @Produce 	 json
-// @Param 		 steamID query string true "Steam ID"
-// @Success 	 200 {object} models.OwnedGamesResponse
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name"
+// @Param 		 sort query string false "Sort key" Enums(playtime, name, appid, last_played)
+// @Param 		 order query string false "Sort order; defaults to desc for playtime and last_played, asc otherwise" Enums(asc, desc)
+// @Param 		 minPlaytime query int false "Minimum playtime in minutes, inclusive"
+// @Param 		 maxPlaytime query int false "Maximum playtime in minutes, inclusive"
+// @Param 		 played query bool false "true for games played at least once, false for never played"
+// @Param 		 q query string false "Case-insensitive name search"
+// @Param 		 offset query int false "Number of matching games to skip"
+// @Param 		 limit query int false "Page size (max 1000)"
+// @Success 	 200 {object} models.OwnedGamesPage
 // @Failure 	 400 {object} map[string]string
 // @Failure 	 500 {object} apperrors.APIError
 // @Router 		 /games [get]
 func (h *UserHandler) GetOwnedGames(c *gin.Context) {
-	steamID := c.Query("steamID")
-	if steamID == "" {
-		c.JSON(400, gin.H{"error": "steam_id is required"})
+	query, ok := ownedGamesQuery(c)
+	if !ok {
+		return
+	}
+	steamID, ok := h.steamID(c)
+	if !ok {
 		return
 	}
 
-	ownedGames, err := h.steamService.GetOwnedGames(c.Request.Context(), steamID)
+	ownedGames, err := h.steamService.QueryOwnedGames(c.Request.Context(), steamID, query)
 	if err != nil {
 		h.RespondWithError(c, err)
 		return
@@ -73,47 +147,372 @@ func (h *UserHandler) GetOwnedGames(c *gin.Context) {
 	c.JSON(200, ownedGames)
 }
 
+// ownedGamesQuery reads the /games filters, or responds with 400 and returns
+// false if any is invalid.
+func ownedGamesQuery(c *gin.Context) (models.OwnedGamesQuery, bool) {
+	var query models.OwnedGamesQuery
+
+	query.Sort = c.Query("sort")
+	switch query.Sort {
+	case "", models.GameSortAppID, models.GameSortName:
+	case models.GameSortPlaytime, models.GameSortLastPlayed:
+		query.Descending = true
+	default:
+		c.JSON(400, gin.H{"error": "sort must be playtime, name, appid or last_played"})
+		return query, false
+	}
+	switch c.Query("order") {
+	case "":
+	case "asc", "desc":
+		if query.Sort == "" {
+			c.JSON(400, gin.H{"error": "order requires sort"})
+			return query, false
+		}
+		query.Descending = c.Query("order") == "desc"
+	default:
+		c.JSON(400, gin.H{"error": "order must be asc or desc"})
+		return query, false
+	}
+
+	var ok bool
+	if query.MinPlaytime, ok = optionalQueryInt(c, "minPlaytime", maxPlaytime); !ok {
+		return query, false
+	}
+	if query.MaxPlaytime, ok = optionalQueryInt(c, "maxPlaytime", maxPlaytime); !ok {
+		return query, false
+	}
+	if query.MinPlaytime != nil && query.MaxPlaytime != nil && *query.MinPlaytime > *query.MaxPlaytime {
+		c.JSON(400, gin.H{"error": "minPlaytime must not exceed maxPlaytime"})
+		return query, false
+	}
+
+	switch c.Query("played") {
+	case "":
+	case "true", "false":
+		played := c.Query("played") == "true"
+		query.Played = &played
+	default:
+		c.JSON(400, gin.H{"error": "played must be true or false"})
+		return query, false
+	}
+
+	query.Search = strings.TrimSpace(c.Query("q"))
+	if query.Offset, ok = queryInt(c, "offset", maxGamesOffset); !ok {
+		return query, false
+	}
+	if query.Limit, ok = queryInt(c, "limit", maxGamesLimit); !ok {
+		return query, false
+	}
+	return query, true
+}
+
+// maxTopGames caps the top query parameter of /games/stats.
+const maxTopGames = 100
+
+// GetLibraryStats godoc
+// @Summary 	 returns analytics over the user's owned games
+// @Description  Totals, median playtime, never-played games, the most played games, a playtime distribution and the share of games with community stats. Playtimes are in minutes.
+// @Tags 	 	 gamesInfo
+// @Produce 	 json
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name"
+// @Param 		 top query int false "Number of most played games to list (default 10, max 100)"
+// @Success 	 200 {object} models.LibraryStats
+// @Failure 	 400 {object} map[string]string
+// @Failure 	 500 {object} apperrors.APIError
+// @Router 		 /games/stats [get]
+func (h *UserHandler) GetLibraryStats(c *gin.Context) {
+	top, ok := queryInt(c, "top", maxTopGames)
+	if !ok {
+		return
+	}
+	steamID, ok := h.steamID(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.steamService.GetLibraryStats(c.Request.Context(), steamID, top)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, stats)
+}
+
+// GetRecentlyPlayedGames godoc
+// @Summary 	 returns games the user played in the last two weeks
+// @Tags 	 	 gamesInfo
+// @Produce 	 json
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name"
+// @Success 	 200 {object} models.RecentlyPlayedGamesResponse
+// @Failure 	 400 {object} map[string]string
+// @Failure 	 500 {object} apperrors.APIError
+// @Router 		 /recent [get]
+func (h *UserHandler) GetRecentlyPlayedGames(c *gin.Context) {
+	steamID, ok := h.steamID(c)
+	if !ok {
+		return
+	}
+
+	recentGames, err := h.steamService.GetRecentlyPlayedGames(c.Request.Context(), steamID)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, recentGames)
+}
+
 // GetUserSummary godoc
 // @Summary 	 returns general info about the user
+// @Description  Returns the decoded player profile. Pass raw=true to get Steam's original GetPlayerSummaries payload (models.Summary) instead; include is ignored in that mode.
 // @Tags 	 	 steamProfile
 // @Produce 	 json
-// @Param 		 steamID query string true "Steam ID"
-// @Success 	 200 {object} models.Summary
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name"
+// @Param 		 raw query bool false "Return Steam's raw payload"
+// @Param 		 include query string false "Comma-separated extra blocks to include; bans adds the player's ban record" Enums(bans)
+// @Success 	 200 {object} models.Player
 // @Failure 	 400 {object} map[string]string
 // @Failure 	 404 {object} apperrors.APIError
 // @Failure 	 500 {object} apperrors.APIError
 // @Router 	 	 /summary [get]
 func (h *UserHandler) GetUserSummary(c *gin.Context) {
-	steamID := c.Query("steamID")
-	if steamID == "" {
-		c.JSON(400, gin.H{"error": "steam_id is required"})
+	steamID, ok := h.steamID(c)
+	if !ok {
+		return
+	}
+
+	if c.Query("raw") == "true" {
+		summary, err := h.steamService.GetPlayerSummaries(c.Request.Context(), steamID)
+		if err != nil {
+			h.RespondWithError(c, err)
+			return
+		}
+
+		c.JSON(200, summary)
 		return
 	}
 
-	summary, err := h.steamService.GetPlayerSummaries(c.Request.Context(), steamID)
+	player, err := h.steamService.GetPlayer(c.Request.Context(), steamID)
 	if err != nil {
 		h.RespondWithError(c, err)
 		return
 	}
 
-	c.JSON(200, summary)
+	if slices.Contains(strings.Split(c.Query("include"), ","), "bans") {
+		bans, err := h.steamService.GetPlayerBans(c.Request.Context(), steamID)
+		if err != nil {
+			h.RespondWithError(c, err)
+			return
+		}
+		player.Bans = bans
+	}
+
+	c.JSON(200, player)
+}
+
+// GetUserSummaries godoc
+// @Summary 	 returns general info about many users at once
+// @Description  Accepts up to 1000 steamIDs, either as a JSON body (POST) or as repeated or comma-separated steamIDs query values (GET). The response is keyed by SteamID64; ids that couldn't be found carry an error instead of a player. If Steam fails for every id, its error is returned instead. Vanity names and /id/ URLs are not accepted.
+// @Tags 	 	 steamProfile
+// @Accept 		 json
+// @Produce 	 json
+// @Param 		 steamIDs query []string false "SteamID64, SteamID2, SteamID3 or profile URL values" collectionFormat(multi)
+// @Param 		 request body models.PlayerSummariesRequest false "Steam IDs"
+// @Success 	 200 {object} map[string]models.PlayerSummaryResult
+// @Failure 	 400 {object} map[string]string
+// @Failure 	 500 {object} apperrors.APIError
+// @Router 	 	 /summaries [get]
+// @Router 	 	 /summaries [post]
+func (h *UserHandler) GetUserSummaries(c *gin.Context) {
+	var steamIDs []string
+	if c.Request.Method == http.MethodPost {
+		var request models.PlayerSummariesRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(400, gin.H{"error": "invalid request body"})
+			return
+		}
+		steamIDs = request.SteamIDs
+	} else {
+		for _, value := range c.QueryArray("steamIDs") {
+			steamIDs = append(steamIDs, strings.Split(value, ",")...)
+		}
+	}
+	if len(steamIDs) == 0 {
+		c.JSON(400, gin.H{"error": "steamIDs are required"})
+		return
+	}
+
+	summaries, err := h.steamService.GetPlayerSummariesBatch(c.Request.Context(), steamIDs)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, summaries)
+}
+
+// GetSteamLevel godoc
+// @Summary 	 returns the user's Steam level and XP
+// @Description  Fails with 409 when the profile is private.
+// @Tags 	 	 steamProfile
+// @Produce 	 json
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name"
+// @Success 	 200 {object} models.PlayerLevel
+// @Failure 	 400 {object} map[string]string
+// @Failure 	 409 {object} apperrors.APIError
+// @Failure 	 500 {object} apperrors.APIError
+// @Router 	 	 /level [get]
+func (h *UserHandler) GetSteamLevel(c *gin.Context) {
+	steamID, ok := h.steamID(c)
+	if !ok {
+		return
+	}
+
+	level, err := h.steamService.GetSteamLevel(c.Request.Context(), steamID)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, level)
+}
+
+// GetBadges godoc
+// @Summary 	 returns the user's badges with their completion times
+// @Description  Also includes the user's level and XP. Fails with 409 when the profile is private.
+// @Tags 	 	 steamProfile
+// @Produce 	 json
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name"
+// @Success 	 200 {object} models.PlayerBadges
+// @Failure 	 400 {object} map[string]string
+// @Failure 	 409 {object} apperrors.APIError
+// @Failure 	 500 {object} apperrors.APIError
+// @Router 	 	 /badges [get]
+func (h *UserHandler) GetBadges(c *gin.Context) {
+	steamID, ok := h.steamID(c)
+	if !ok {
+		return
+	}
+
+	badges, err := h.steamService.GetBadges(c.Request.Context(), steamID)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, badges)
+}
+
+// GetUserBans godoc
+// @Summary 	 returns VAC, game, community and trade ban status of many users
+// @Description  Accepts up to 1000 repeated or comma-separated steamIDs. The response is keyed by SteamID64; ids that couldn't be found carry an error instead of a ban record. If Steam fails for every id, its error is returned instead. Vanity names and /id/ URLs are not accepted.
+// @Tags 	 	 steamProfile
+// @Produce 	 json
+// @Param 		 steamIDs query []string true "SteamID64, SteamID2, SteamID3 or profile URL values" collectionFormat(multi)
+// @Success 	 200 {object} map[string]models.PlayerBansResult
+// @Failure 	 400 {object} map[string]string
+// @Failure 	 500 {object} apperrors.APIError
+// @Router 	 	 /bans [get]
+func (h *UserHandler) GetUserBans(c *gin.Context) {
+	var steamIDs []string
+	for _, value := range c.QueryArray("steamIDs") {
+		steamIDs = append(steamIDs, strings.Split(value, ",")...)
+	}
+	if len(steamIDs) == 0 {
+		c.JSON(400, gin.H{"error": "steamIDs are required"})
+		return
+	}
+
+	bans, err := h.steamService.GetPlayerBansBatch(c.Request.Context(), steamIDs)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, bans)
+}
+
+// GetFriendList godoc
+// @Summary 	 returns the user's friends and when each friendship started
+// @Description  Pass expand=summary to include each friend's player summary. Fails with 409 when the friend list is not public.
+// @Tags 	 	 steamProfile
+// @Produce 	 json
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name"
+// @Param 		 expand query string false "Set to summary to include friend summaries" Enums(summary)
+// @Success 	 200 {object} models.FriendList
+// @Failure 	 400 {object} map[string]string
+// @Failure 	 409 {object} apperrors.APIError
+// @Failure 	 500 {object} apperrors.APIError
+// @Router 	 	 /friends [get]
+func (h *UserHandler) GetFriendList(c *gin.Context) {
+	steamID, ok := h.steamID(c)
+	if !ok {
+		return
+	}
+
+	friends, err := h.steamService.GetFriendList(c.Request.Context(), steamID, c.Query("expand") == "summary")
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, friends)
+}
+
+// GetUserStats godoc
+// @Summary 	 returns the user's numeric stats in a game
+// @Description  Stats are labelled with the game schema's display names; stats Steam leaves out are reported at their default value. Fails with 409 for private profiles and games without stats.
+// @Tags 		 gamesInfo
+// @Produce 	 json
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name of the user"
+// @Param 		 appID query string true "App ID of the game"
+// @Success 	 200 {object} models.PlayerStats
+// @Failure 	 400 {object} map[string]string
+// @Failure 	 409 {object} apperrors.APIError
+// @Failure 	 500 {object} apperrors.APIError
+// @Router 		 /stats [get]
+func (h *UserHandler) GetUserStats(c *gin.Context) {
+	appID := c.Query("appID")
+	if appID == "" {
+		c.JSON(400, gin.H{"error": "app_id is required"})
+		return
+	}
+	steamID, ok := h.steamID(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.steamService.GetPlayerStats(c.Request.Context(), steamID, appID)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, stats)
 }
 
 // GetUserAchievements
 // @Summary 	 returns all the achievements the user have for a game with all the details
 // @Tags 		 gamesInfo
 // @Produce 	 json
-// @Param 		 steamID query string true "Steam ID of the user"
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name of the user"
 // @Param 		 appID query string true "App ID of the game"
+// @Param 		 revealHidden query bool false "Set to false to hide descriptions of hidden achievements the user hasn't unlocked"
 // @Success 	 200 {object} models.PlayerAchievements
 // @Failure 	 400 {object} map[string]string
 // @Failure 	 409 {object} apperrors.APIError
 // @Failure 	 500 {object} apperrors.APIError
 // @Router 		 /achievements [get]
 func (h *UserHandler) GetUserAchievements(c *gin.Context) {
-	steamID, appID := c.Query("steamID"), c.Query("appID")
-	if steamID == "" || appID == "" {
-		c.JSON(400, gin.H{"error": "steam_id and app_id are required"})
+	appID := c.Query("appID")
+	if appID == "" {
+		c.JSON(400, gin.H{"error": "app_id is required"})
+		return
+	}
+	steamID, ok := h.steamID(c)
+	if !ok {
+		return
 	}
 
 	achievements, err := h.steamService.GetPlayerAchievements(c.Request.Context(), steamID, appID)
@@ -122,5 +521,34 @@ func (h *UserHandler) GetUserAchievements(c *gin.Context) {
 		return
 	}
 
+	if c.Query("revealHidden") == "false" {
+		achievements = achievements.MaskHidden()
+	}
+
 	c.JSON(200, achievements)
 }
+
+// GetAchievementSummary godoc
+// @Summary 	 returns the user's achievement progress across their whole library
+// @Description  Covers every owned game with community stats: per-game unlocked/total, the overall completion rate, perfect games and the rarest unlocked achievements. Large libraries are fetched over several requests: while complete is false, pendingGames games are still being fetched and the summary fills in on later requests.
+// @Tags 		 gamesInfo
+// @Produce 	 json
+// @Param 		 steamID query string true "SteamID64, SteamID2, SteamID3, profile URL or vanity name of the user"
+// @Success 	 200 {object} models.AchievementSummary
+// @Failure 	 400 {object} map[string]string
+// @Failure 	 500 {object} apperrors.APIError
+// @Router 		 /achievements/summary [get]
+func (h *UserHandler) GetAchievementSummary(c *gin.Context) {
+	steamID, ok := h.steamID(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.steamService.GetAchievementSummary(c.Request.Context(), steamID)
+	if err != nil {
+		h.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(200, summary)
+}
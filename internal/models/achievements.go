@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type PlayerAchievementsResponse struct {
 	PlayerStats struct {
@@ -29,6 +32,11 @@ type GameSchemaResponse struct {
 				Icon         string `json:"icon"`
 				IconGray     string `json:"icongray"`
 			} `json:"achievements"`
+			Stats []struct {
+				Name         string  `json:"name"`
+				DefaultValue float64 `json:"defaultvalue"`
+				DisplayName  string  `json:"displayName"`
+			} `json:"stats"`
 		} `json:"availableGameStats"`
 	} `json:"game"`
 }
@@ -52,10 +60,98 @@ type Achievement struct {
 	Icon        string    `json:"icon"`
 	IconGray    string    `json:"iconGray"`
 	Rarity      float64   `json:"rarity"` // Percentage of players who have this achievement
+	Hidden      bool      `json:"hidden"`
+	// Orphaned marks achievements the player has but the game schema no
+	// longer lists; only Name, Achieved, UnlockTime and Rarity are known.
+	Orphaned bool `json:"orphaned,omitempty"`
 }
 
 type PlayerAchievements struct {
 	SteamID      string        `json:"steamID"`
 	GameName     string        `json:"gameName"`
 	Achievements []Achievement `json:"achievements"`
+	// RarityUnavailable is set when global percentages could not be fetched
+	// and every Rarity was left at 0.
+	RarityUnavailable bool `json:"rarityUnavailable,omitempty"`
+}
+
+// MaskHidden returns a copy of p in which hidden achievements the player
+// hasn't unlocked have no description, as the Steam client shows them.
+func (p *PlayerAchievements) MaskHidden() *PlayerAchievements {
+	masked := *p
+	masked.Achievements = slices.Clone(p.Achievements)
+	for i, ach := range masked.Achievements {
+		if ach.Hidden && !ach.Achieved {
+			masked.Achievements[i].Description = ""
+		}
+	}
+	return &masked
+}
+
+// Partial reports whether the response was assembled from incomplete data
+// and should not be cached.
+func (p *PlayerAchievements) Partial() bool {
+	return p.RarityUnavailable
+}
+
+// GlobalAchievement is an achievement of a game with the share of all its
+// players who unlocked it.
+type GlobalAchievement struct {
+	Name        string  `json:"name"`
+	DisplayName string  `json:"displayName"`
+	Description string  `json:"description"`
+	Icon        string  `json:"icon"`
+	IconGray    string  `json:"iconGray"`
+	Hidden      bool    `json:"hidden"`
+	Percent     float64 `json:"percent"`
+}
+
+type GameAchievements struct {
+	AppID        string              `json:"appID"`
+	GameName     string              `json:"gameName"`
+	Achievements []GlobalAchievement `json:"achievements"`
+}
+
+// GameCompletion is a user's achievement progress in one game.
+type GameCompletion struct {
+	AppID      int     `json:"appID"`
+	Name       string  `json:"name"`
+	Unlocked   int     `json:"unlocked"`
+	Total      int     `json:"total"`
+	Percentage float64 `json:"percentage"`
+}
+
+// RareAchievement is an unlocked achievement together with its game.
+type RareAchievement struct {
+	AppID    int    `json:"appID"`
+	GameName string `json:"gameName"`
+	Achievement
+}
+
+// AchievementSummary is a user's achievement progress across their whole
+// library. Percentages are on a 0-100 scale, like Achievement.Rarity.
+type AchievementSummary struct {
+	SteamID  string `json:"steamID"`
+	Unlocked int    `json:"unlocked"`
+	Total    int    `json:"total"`
+	// CompletionRate is Unlocked over Total; AverageCompletion is the mean
+	// of the per-game percentages of games with at least one unlock, which
+	// is what Steam shows on profiles.
+	CompletionRate    float64           `json:"completionRate"`
+	AverageCompletion float64           `json:"averageCompletion"`
+	PerfectGames      int               `json:"perfectGames"`
+	Games             []GameCompletion  `json:"games"`
+	RarestUnlocked    []RareAchievement `json:"rarestUnlocked"`
+	// Complete is false when some games could not be fetched in time; they
+	// are counted in PendingGames and fill in on a later request as their
+	// results reach the cache.
+	Complete     bool `json:"complete"`
+	PendingGames int  `json:"pendingGames"`
+	// SkippedGames counts games Steam returned no achievements for.
+	SkippedGames int `json:"skippedGames"`
+}
+
+// Partial reports whether some games are still missing from the summary.
+func (s *AchievementSummary) Partial() bool {
+	return !s.Complete
 }
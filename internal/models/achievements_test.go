@@ -0,0 +1,29 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskHidden(t *testing.T) {
+	original := &models.PlayerAchievements{
+		GameName: "Test Game",
+		Achievements: []models.Achievement{
+			{Name: "ACH_PLAIN", Description: "Win a match"},
+			{Name: "ACH_SECRET_DONE", Description: "Beat the secret boss", Hidden: true, Achieved: true},
+			{Name: "ACH_SECRET", Description: "Find the hidden room", Hidden: true},
+		},
+	}
+
+	masked := original.MaskHidden()
+
+	assert.Equal(t, "Win a match", masked.Achievements[0].Description)
+	assert.Equal(t, "Beat the secret boss", masked.Achievements[1].Description)
+	assert.Empty(t, masked.Achievements[2].Description)
+	assert.Equal(t, "Test Game", masked.GameName)
+
+	// The original, which may be shared through the cache, is untouched
+	assert.Equal(t, "Find the hidden room", original.Achievements[2].Description)
+}
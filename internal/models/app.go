@@ -0,0 +1,94 @@
+package models
+
+// AppDetailsResponse mirrors the store's appdetails API, which is keyed by
+// the requested appID.
+type AppDetailsResponse map[string]struct {
+	Success bool          `json:"success"`
+	Data    *StoreAppData `json:"data"`
+}
+
+type StoreAppData struct {
+	Type             string   `json:"type"`
+	Name             string   `json:"name"`
+	SteamAppID       int      `json:"steam_appid"`
+	IsFree           bool     `json:"is_free"`
+	ShortDescription string   `json:"short_description"`
+	HeaderImage      string   `json:"header_image"`
+	Website          string   `json:"website"`
+	Developers       []string `json:"developers"`
+	Publishers       []string `json:"publishers"`
+	PriceOverview    *struct {
+		Currency         string `json:"currency"`
+		Initial          int    `json:"initial"`
+		Final            int    `json:"final"`
+		DiscountPercent  int    `json:"discount_percent"`
+		InitialFormatted string `json:"initial_formatted"`
+		FinalFormatted   string `json:"final_formatted"`
+	} `json:"price_overview"`
+	Platforms struct {
+		Windows bool `json:"windows"`
+		Mac     bool `json:"mac"`
+		Linux   bool `json:"linux"`
+	} `json:"platforms"`
+	Metacritic *struct {
+		Score int    `json:"score"`
+		URL   string `json:"url"`
+	} `json:"metacritic"`
+	Categories []struct {
+		Description string `json:"description"`
+	} `json:"categories"`
+	Genres []struct {
+		Description string `json:"description"`
+	} `json:"genres"`
+	ReleaseDate struct {
+		ComingSoon bool   `json:"coming_soon"`
+		Date       string `json:"date"`
+	} `json:"release_date"`
+}
+
+// App is a game's store page, localized for one country and language.
+type App struct {
+	AppID            int         `json:"appID"`
+	Type             string      `json:"type"` // game, dlc, demo, music...
+	Name             string      `json:"name"`
+	ShortDescription string      `json:"shortDescription,omitempty"`
+	HeaderImage      string      `json:"headerImage,omitempty"`
+	Website          string      `json:"website,omitempty"`
+	Developers       []string    `json:"developers"`
+	Publishers       []string    `json:"publishers"`
+	Genres           []string    `json:"genres"`
+	Categories       []string    `json:"categories"`
+	ReleaseDate      ReleaseDate `json:"releaseDate"`
+	Platforms        Platforms   `json:"platforms"`
+	Metacritic       *Metacritic `json:"metacritic,omitempty"`
+	IsFree           bool        `json:"isFree"`
+	// Price is missing for free apps and apps not sold in the country.
+	Price *Price `json:"price,omitempty"`
+}
+
+type ReleaseDate struct {
+	ComingSoon bool `json:"comingSoon"`
+	// Date is the store's localized, free-form release date.
+	Date string `json:"date"`
+}
+
+type Platforms struct {
+	Windows bool `json:"windows"`
+	Mac     bool `json:"mac"`
+	Linux   bool `json:"linux"`
+}
+
+type Metacritic struct {
+	Score int    `json:"score"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Price amounts are in the currency's smallest unit (e.g. cents).
+type Price struct {
+	Currency         string `json:"currency"`
+	Initial          int    `json:"initial"`
+	Final            int    `json:"final"`
+	DiscountPercent  int    `json:"discountPercent"`
+	InitialFormatted string `json:"initialFormatted"`
+	FinalFormatted   string `json:"finalFormatted"`
+}
@@ -0,0 +1,44 @@
+package models
+
+// PlayerBansResponse mirrors ISteamUser/GetPlayerBans. Unlike most Steam
+// endpoints it has no "response" envelope.
+type PlayerBansResponse struct {
+	Players []struct {
+		SteamID          string `json:"SteamId"`
+		CommunityBanned  bool   `json:"CommunityBanned"`
+		VACBanned        bool   `json:"VACBanned"`
+		NumberOfVACBans  int    `json:"NumberOfVACBans"`
+		DaysSinceLastBan int    `json:"DaysSinceLastBan"`
+		NumberOfGameBans int    `json:"NumberOfGameBans"`
+		EconomyBan       string `json:"EconomyBan"`
+	} `json:"players"`
+}
+
+// EconomyBan is a player's trade ban status.
+type EconomyBan string
+
+const (
+	EconomyBanNone      EconomyBan = "none"
+	EconomyBanProbation EconomyBan = "probation"
+	EconomyBanBanned    EconomyBan = "banned"
+)
+
+// PlayerBans is the ban record of one player.
+type PlayerBans struct {
+	SteamID         string     `json:"steamID"`
+	VACBanned       bool       `json:"vacBanned"`
+	VACBans         int        `json:"vacBans"`
+	GameBans        int        `json:"gameBans"`
+	CommunityBanned bool       `json:"communityBanned"`
+	EconomyBan      EconomyBan `json:"economyBan"`
+	// DaysSinceLastBan is 0 both for players banned today and for players
+	// that were never banned.
+	DaysSinceLastBan int `json:"daysSinceLastBan"`
+}
+
+// PlayerBansResult is one entry of a batch bans lookup: either the ban record
+// or the reason it couldn't be returned.
+type PlayerBansResult struct {
+	Bans  *PlayerBans `json:"bans,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
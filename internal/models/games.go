@@ -1,15 +1,65 @@
 package models
 
+// OwnedGame is one entry of Steam's owned or recently played games list.
+// Playtimes are in minutes.
+type OwnedGame struct {
+	AppID                    int    `json:"appid"`
+	Name                     string `json:"name"`
+	PlaytimeForever          int    `json:"playtime_forever"`
+	Playtime2Weeks           int    `json:"playtime_2weeks,omitempty"`
+	PlaytimeWindowsForever   int    `json:"playtime_windows_forever"`
+	PlaytimeMacForever       int    `json:"playtime_mac_forever"`
+	PlaytimeLinuxForever     int    `json:"playtime_linux_forever"`
+	PlaytimeDeckForever      int    `json:"playtime_deck_forever"`
+	PlaytimeDisconnected     int    `json:"playtime_disconnected"`
+	RTimeLastPlayed          int64  `json:"rtime_last_played,omitempty"` // unix seconds, 0 when never played
+	ImgIconURL               string `json:"img_icon_url"`
+	ImgLogoURL               string `json:"img_logo_url"`
+	HasCommunityVisibleStats bool   `json:"has_community_visible_stats,omitempty"`
+}
+
 type OwnedGamesResponse struct {
 	Response struct {
-		GameCount int `json:"game_count"`
-		Games     []struct {
-			AppID                    int    `json:"appid"`
-			Name                     string `json:"name"`
-			PlaytimeForever          int    `json:"playtime_forever"`
-			ImgIconURL               string `json:"img_icon_url"`
-			ImgLogoURL               string `json:"img_logo_url"`
-			HasCommunityVisibleStats bool   `json:"has_community_visible_stats,omitempty"`
-		} `json:"games"`
+		GameCount int         `json:"game_count"`
+		Games     []OwnedGame `json:"games"`
+	} `json:"response"`
+}
+
+type RecentlyPlayedGamesResponse struct {
+	Response struct {
+		TotalCount int         `json:"total_count"`
+		Games      []OwnedGame `json:"games"`
+	} `json:"response"`
+}
+
+// Sort keys of OwnedGamesQuery.
+const (
+	GameSortAppID      = "appid"
+	GameSortName       = "name"
+	GameSortPlaytime   = "playtime"
+	GameSortLastPlayed = "last_played"
+)
+
+// OwnedGamesQuery filters, sorts and pages an owned games list. Zero values
+// disable each option; a zero Limit returns every match.
+type OwnedGamesQuery struct {
+	Sort        string
+	Descending  bool
+	MinPlaytime *int // minutes, inclusive
+	MaxPlaytime *int // minutes, inclusive
+	Played      *bool
+	Search      string // case-insensitive substring of the name
+	Offset      int
+	Limit       int
+}
+
+// OwnedGamesPage is one page of an owned games list. GameCount is the size
+// of the whole library and Total the number of games matching the filters.
+type OwnedGamesPage struct {
+	Response struct {
+		GameCount int         `json:"game_count"`
+		Total     int         `json:"total"`
+		Offset    int         `json:"offset"`
+		Games     []OwnedGame `json:"games"`
 	} `json:"response"`
 }
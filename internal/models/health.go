@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type BreakerStatus struct {
+	State    string     `json:"state"`
+	Failures int        `json:"failures"`
+	OpenedAt *time.Time `json:"openedAt,omitempty"`
+}
+
+type Health struct {
+	Status   string                   `json:"status"`
+	Breakers map[string]BreakerStatus `json:"breakers"`
+}
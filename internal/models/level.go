@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+type SteamLevelResponse struct {
+	Response struct {
+		// PlayerLevel is missing when the profile is private.
+		PlayerLevel *int `json:"player_level"`
+	} `json:"response"`
+}
+
+type BadgesResponse struct {
+	Response struct {
+		Badges []struct {
+			BadgeID         int    `json:"badgeid"`
+			AppID           int    `json:"appid,omitempty"`
+			CommunityItemID string `json:"communityitemid,omitempty"`
+			Level           int    `json:"level"`
+			CompletionTime  int64  `json:"completion_time"`
+			XP              int    `json:"xp"`
+			Scarcity        int    `json:"scarcity"`
+			BorderColor     int    `json:"border_color,omitempty"`
+		} `json:"badges"`
+		// PlayerXP is missing when the profile is private.
+		PlayerXP                   *int `json:"player_xp"`
+		PlayerLevel                int  `json:"player_level"`
+		PlayerXPNeededToLevelUp    int  `json:"player_xp_needed_to_level_up"`
+		PlayerXPNeededCurrentLevel int  `json:"player_xp_needed_current_level"`
+	} `json:"response"`
+}
+
+// PlayerLevel is a player's Steam level and their progress towards the next.
+type PlayerLevel struct {
+	SteamID string `json:"steamID"`
+	Level   int    `json:"level"`
+	XP      int    `json:"xp"`
+	// XPToNextLevel is how much more XP the player needs to level up.
+	XPToNextLevel int `json:"xpToNextLevel"`
+	// XPCurrentLevel is the total XP the current level required.
+	XPCurrentLevel int `json:"xpCurrentLevel"`
+}
+
+type Badge struct {
+	BadgeID int `json:"badgeID"`
+	// AppID is set for game badges (trading cards) only.
+	AppID       int       `json:"appID,omitempty"`
+	Level       int       `json:"level"`
+	XP          int       `json:"xp"`
+	Scarcity    int       `json:"scarcity"` // how many players own the badge
+	Foil        bool      `json:"foil,omitempty"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+type PlayerBadges struct {
+	PlayerLevel
+	Count  int     `json:"count"`
+	Badges []Badge `json:"badges"`
+}
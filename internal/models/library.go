@@ -0,0 +1,27 @@
+package models
+
+// LibraryStats summarizes a user's owned games. Playtimes are in minutes.
+type LibraryStats struct {
+	SteamID       string `json:"steamID"`
+	TotalGames    int    `json:"totalGames"`
+	TotalPlaytime int    `json:"totalPlaytime"`
+	// MedianPlaytime is taken over every owned game, played or not.
+	MedianPlaytime float64 `json:"medianPlaytime"`
+	// NeverPlayed counts owned games with no recorded playtime, the "pile
+	// of shame".
+	NeverPlayed             int              `json:"neverPlayed"`
+	NeverPlayedShare        float64          `json:"neverPlayedShare"`
+	GamesWithCommunityStats int              `json:"gamesWithCommunityStats"`
+	CommunityStatsShare     float64          `json:"communityStatsShare"`
+	TopGames                []OwnedGame      `json:"topGames"`
+	Distribution            []PlaytimeBucket `json:"distribution"`
+}
+
+// PlaytimeBucket counts games whose playtime is within [MinPlaytime,
+// MaxPlaytime]. The last bucket has no upper bound.
+type PlaytimeBucket struct {
+	Label       string `json:"label"`
+	MinPlaytime int    `json:"minPlaytime"`
+	MaxPlaytime *int   `json:"maxPlaytime,omitempty"`
+	Games       int    `json:"games"`
+}
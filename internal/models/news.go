@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type NewsForAppResponse struct {
+	AppNews struct {
+		AppID     int `json:"appid"`
+		NewsItems []struct {
+			GID           string `json:"gid"`
+			Title         string `json:"title"`
+			URL           string `json:"url"`
+			IsExternalURL bool   `json:"is_external_url"`
+			Author        string `json:"author"`
+			Contents      string `json:"contents"`
+			FeedLabel     string `json:"feedlabel"`
+			Date          int64  `json:"date"`
+			FeedName      string `json:"feedname"`
+		} `json:"newsitems"`
+		Count int `json:"count"`
+	} `json:"appnews"`
+}
+
+// NewsFilter narrows down GetNewsForApp results. Zero values use Steam's
+// defaults.
+type NewsFilter struct {
+	Count int
+	// MaxLength truncates contents to that many characters.
+	MaxLength int
+	// Feeds limits the result to these feed names, e.g. steam_community_announcements.
+	Feeds []string
+}
+
+type NewsItem struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	External  bool      `json:"external"`
+	Author    string    `json:"author,omitempty"`
+	Contents  string    `json:"contents"`
+	FeedName  string    `json:"feedName"`
+	FeedLabel string    `json:"feedLabel"`
+	Date      time.Time `json:"date"`
+}
+
+type AppNews struct {
+	AppID int `json:"appID"`
+	// Total is how many news items Steam has for the app, not how many are
+	// returned.
+	Total int        `json:"total"`
+	Items []NewsItem `json:"items"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type PersonaState string
+
+const (
+	PersonaOffline        PersonaState = "offline"
+	PersonaOnline         PersonaState = "online"
+	PersonaBusy           PersonaState = "busy"
+	PersonaAway           PersonaState = "away"
+	PersonaSnooze         PersonaState = "snooze"
+	PersonaLookingToTrade PersonaState = "looking_to_trade"
+	PersonaLookingToPlay  PersonaState = "looking_to_play"
+	PersonaUnknown        PersonaState = "unknown"
+)
+
+// personaStates maps Steam's personastate codes.
+var personaStates = map[int]PersonaState{
+	0: PersonaOffline,
+	1: PersonaOnline,
+	2: PersonaBusy,
+	3: PersonaAway,
+	4: PersonaSnooze,
+	5: PersonaLookingToTrade,
+	6: PersonaLookingToPlay,
+}
+
+type Visibility string
+
+const (
+	VisibilityPrivate     Visibility = "private"
+	VisibilityFriendsOnly Visibility = "friends_only"
+	VisibilityPublic      Visibility = "public"
+	VisibilityUnknown     Visibility = "unknown"
+)
+
+// visibilities maps Steam's communityvisibilitystate codes.
+var visibilities = map[int]Visibility{
+	1: VisibilityPrivate,
+	2: VisibilityFriendsOnly,
+	3: VisibilityPublic,
+}
+
+const steamCommunityProfileURL = "https://steamcommunity.com/profiles/"
+
+type PlayerAvatar struct {
+	Small  string `json:"small"`
+	Medium string `json:"medium"`
+	Full   string `json:"full"`
+}
+
+// Player is the decoded form of a PlayerSummary: enum codes become names,
+// unix timestamps become times, and the profile URL is always the permanent
+// /profiles/<steamID> link.
+type Player struct {
+	SteamID           string       `json:"steamID"`
+	PersonaName       string       `json:"personaName"`
+	RealName          string       `json:"realName,omitempty"`
+	ProfileURL        string       `json:"profileURL"`
+	CustomURL         string       `json:"customURL,omitempty"` // Steam's vanity URL, if the player set one
+	Avatar            PlayerAvatar `json:"avatar"`
+	PersonaState      PersonaState `json:"personaState"`
+	Visibility        Visibility   `json:"visibility"`
+	ProfileConfigured bool         `json:"profileConfigured"`
+	CommentsAllowed   bool         `json:"commentsAllowed"`
+	LastLogoff        *time.Time   `json:"lastLogoff,omitempty"`
+	CreatedAt         *time.Time   `json:"createdAt,omitempty"`
+	PrimaryClanID     string       `json:"primaryClanID,omitempty"`
+	CountryCode       string       `json:"countryCode,omitempty"`
+	StateCode         string       `json:"stateCode,omitempty"`
+	// Bans is only filled in when explicitly requested.
+	Bans *PlayerBans `json:"bans,omitempty"`
+}
+
+func (p PlayerSummary) Player() Player {
+	player := Player{
+		SteamID:           p.SteamID,
+		PersonaName:       p.PersonaName,
+		RealName:          p.RealName,
+		ProfileURL:        steamCommunityProfileURL + p.SteamID + "/",
+		Avatar:            PlayerAvatar{Small: p.Avatar, Medium: p.AvatarMedium, Full: p.AvatarFull},
+		PersonaState:      PersonaUnknown,
+		Visibility:        VisibilityUnknown,
+		ProfileConfigured: p.ProfileState == 1,
+		CommentsAllowed:   p.CommentPermission == 1,
+		LastLogoff:        unixTime(p.LastLogoff),
+		CreatedAt:         unixTime(p.TimeCreated),
+		PrimaryClanID:     p.PrimaryClanID,
+		CountryCode:       p.LocCountryCode,
+		StateCode:         p.LocStateCode,
+	}
+
+	if state, ok := personaStates[p.PersonaState]; ok {
+		player.PersonaState = state
+	}
+	if visibility, ok := visibilities[p.CommunityVisibilityState]; ok {
+		player.Visibility = visibility
+	}
+	if strings.Contains(p.ProfileURL, "steamcommunity.com/id/") {
+		player.CustomURL = p.ProfileURL
+	}
+
+	return player
+}
+
+// unixTime converts a Steam timestamp, where 0 means "not set".
+func unixTime(seconds int) *time.Time {
+	if seconds <= 0 {
+		return nil
+	}
+	t := time.Unix(int64(seconds), 0).UTC()
+	return &t
+}
@@ -0,0 +1,42 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerSummaryDecoding(t *testing.T) {
+	summary := models.PlayerSummary{
+		SteamID:                  "76561197960287930",
+		PersonaName:              "Rabscuttle",
+		ProfileURL:               "https://steamcommunity.com/id/gabelogannewell/",
+		CommunityVisibilityState: 3,
+		ProfileState:             1,
+		PersonaState:             5,
+		LastLogoff:               1700000000,
+		TimeCreated:              0,
+	}
+
+	player := summary.Player()
+
+	assert.Equal(t, "https://steamcommunity.com/profiles/76561197960287930/", player.ProfileURL)
+	assert.Equal(t, "https://steamcommunity.com/id/gabelogannewell/", player.CustomURL)
+	assert.Equal(t, models.PersonaLookingToTrade, player.PersonaState)
+	assert.Equal(t, models.VisibilityPublic, player.Visibility)
+	assert.True(t, player.ProfileConfigured)
+	require.NotNil(t, player.LastLogoff)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), *player.LastLogoff)
+	assert.Nil(t, player.CreatedAt)
+}
+
+func TestPlayerSummaryUnknownCodes(t *testing.T) {
+	player := models.PlayerSummary{SteamID: "76561197960287930", PersonaState: 42}.Player()
+
+	assert.Equal(t, models.PersonaUnknown, player.PersonaState)
+	assert.Equal(t, models.VisibilityUnknown, player.Visibility)
+	assert.Empty(t, player.CustomURL)
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type NumberOfCurrentPlayersResponse struct {
+	Response struct {
+		PlayerCount int `json:"player_count"`
+		Result      int `json:"result"` // 1 on success
+	} `json:"response"`
+}
+
+type CurrentPlayers struct {
+	AppID   int `json:"appID"`
+	Players int `json:"players"`
+}
+
+// PlayerCountBucket aggregates the samples taken in [Start, Start+bucket).
+type PlayerCountBucket struct {
+	Start   time.Time `json:"start"`
+	Min     int       `json:"min"`
+	Max     int       `json:"max"`
+	Avg     float64   `json:"avg"`
+	Samples int       `json:"samples"`
+}
+
+type PlayerCountHistory struct {
+	AppID  int       `json:"appID"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Bucket string    `json:"bucket"`
+	// Buckets without samples are left out.
+	Buckets []PlayerCountBucket `json:"buckets"`
+}
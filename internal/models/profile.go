@@ -1,26 +1,85 @@
 package models
 
+import "time"
+
 type Summary struct {
 	Response struct {
-		Players []struct {
-			SteamID                  string `json:"steamid"`
-			CommunityVisibilityState int    `json:"communityvisibilitystate"`
-			ProfileState             int    `json:"profilestate"`
-			PersonaName              string `json:"personaname"`
-			CommentPermission        int    `json:"commentpermission"`
-			ProfileURL               string `json:"profileurl"`
-			Avatar                   string `json:"avatar"`
-			AvatarMedium             string `json:"avatarmedium"`
-			AvatarFull               string `json:"avatarfull"`
-			AvatarHash               string `json:"avatarhash"`
-			LastLogoff               int    `json:"lastlogoff"`
-			PersonaState             int    `json:"personastate"`
-			RealName                 string `json:"realname"`
-			PrimaryClanID            string `json:"primaryclanid"`
-			TimeCreated              int    `json:"timecreated"`
-			PersonaStateFlags        int    `json:"personastateflags"`
-			LocCountryCode           string `json:"loccountrycode"`
-			LocStateCode             string `json:"locstatecode"`
-		} `json:"players"`
+		Players []PlayerSummary `json:"players"`
+	} `json:"response"`
+}
+
+type PlayerSummary struct {
+	SteamID                  string `json:"steamid"`
+	CommunityVisibilityState int    `json:"communityvisibilitystate"`
+	ProfileState             int    `json:"profilestate"`
+	PersonaName              string `json:"personaname"`
+	CommentPermission        int    `json:"commentpermission"`
+	ProfileURL               string `json:"profileurl"`
+	Avatar                   string `json:"avatar"`
+	AvatarMedium             string `json:"avatarmedium"`
+	AvatarFull               string `json:"avatarfull"`
+	AvatarHash               string `json:"avatarhash"`
+	LastLogoff               int    `json:"lastlogoff"`
+	PersonaState             int    `json:"personastate"`
+	RealName                 string `json:"realname"`
+	PrimaryClanID            string `json:"primaryclanid"`
+	TimeCreated              int    `json:"timecreated"`
+	PersonaStateFlags        int    `json:"personastateflags"`
+	LocCountryCode           string `json:"loccountrycode"`
+	LocStateCode             string `json:"locstatecode"`
+}
+
+type ResolveVanityURLResponse struct {
+	Response struct {
+		SteamID string `json:"steamid"`
+		Success int    `json:"success"`
+		Message string `json:"message,omitempty"`
 	} `json:"response"`
 }
+
+// SteamIDConversion lists every representation of one SteamID.
+type SteamIDConversion struct {
+	SteamID64   string `json:"steamID64"`
+	SteamID2    string `json:"steamID2,omitempty"` // only individual accounts have one
+	SteamID3    string `json:"steamID3"`
+	AccountID   uint32 `json:"accountID"`
+	Universe    string `json:"universe"`
+	AccountType string `json:"accountType"`
+	Instance    uint32 `json:"instance"`
+	ProfileURL  string `json:"profileURL,omitempty"`
+}
+
+// PlayerSummaryResult is one entry of a batch summaries lookup: either the
+// player or the reason it couldn't be returned.
+type PlayerSummaryResult struct {
+	Player *PlayerSummary `json:"player,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+type PlayerSummariesRequest struct {
+	SteamIDs []string `json:"steamIDs"`
+}
+
+type FriendListResponse struct {
+	FriendsList struct {
+		Friends []struct {
+			SteamID      string `json:"steamid"`
+			Relationship string `json:"relationship"`
+			FriendSince  int64  `json:"friend_since"`
+		} `json:"friends"`
+	} `json:"friendslist"`
+}
+
+type Friend struct {
+	SteamID      string    `json:"steamID"`
+	Relationship string    `json:"relationship"`
+	FriendSince  time.Time `json:"friendSince"`
+	// Player is only filled in when the list is expanded with summaries.
+	Player *Player `json:"player,omitempty"`
+}
+
+type FriendList struct {
+	SteamID string   `json:"steamID"`
+	Count   int      `json:"count"`
+	Friends []Friend `json:"friends"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// QuotaUsage is today's Steam API usage. Budget applies to each key
+// separately; Used, Remaining and Endpoints add up every key.
+type QuotaUsage struct {
+	Date      string                   `json:"date"`
+	Budget    int                      `json:"budget"`
+	Used      int                      `json:"used"`
+	Remaining int                      `json:"remaining"`
+	Endpoints map[string]int           `json:"endpoints"`
+	Keys      map[string]KeyQuotaUsage `json:"keys"` // by key id
+}
+
+type KeyQuotaUsage struct {
+	Key       string         `json:"key"` // the key's last four characters
+	Used      int            `json:"used"`
+	Remaining int            `json:"remaining"`
+	Endpoints map[string]int `json:"endpoints"`
+}
@@ -0,0 +1,29 @@
+package models
+
+type UserStatsForGameResponse struct {
+	PlayerStats struct {
+		SteamID  string `json:"steamID"`
+		GameName string `json:"gameName"`
+		// Stats still at their default value are left out by Steam.
+		Stats []struct {
+			Name  string  `json:"name"`
+			Value float64 `json:"value"`
+		} `json:"stats"`
+	} `json:"playerstats"`
+}
+
+// PlayerStat is one numeric stat (kills, wins, distance...) of a player in a
+// game, labelled with the schema's display name.
+type PlayerStat struct {
+	Name         string  `json:"name"`
+	DisplayName  string  `json:"displayName"`
+	Value        float64 `json:"value"`
+	DefaultValue float64 `json:"defaultValue"`
+}
+
+type PlayerStats struct {
+	SteamID  string       `json:"steamID"`
+	AppID    string       `json:"appID"`
+	GameName string       `json:"gameName"`
+	Stats    []PlayerStat `json:"stats"`
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type PlayerCountRepository interface {
+	SaveSample(appID int, sampledAt time.Time, players int) error
+	// GetHistory aggregates appID's samples in [from, to) into buckets of
+	// the given width, aligned to the unix epoch.
+	GetHistory(appID int, from, to time.Time, bucket time.Duration) ([]models.PlayerCountBucket, error)
+}
+
+type playerCountRepository struct {
+	db *sqlx.DB
+}
+
+func NewPlayerCountRepository(db *sqlx.DB) PlayerCountRepository {
+	return &playerCountRepository{db: db}
+}
+
+func (r *playerCountRepository) SaveSample(appID int, sampledAt time.Time, players int) error {
+	_, err := r.db.Exec(
+		`INSERT INTO player_counts (app_id, sampled_at, players) VALUES ($1, $2, $3)
+		 ON CONFLICT (app_id, sampled_at) DO NOTHING`,
+		appID, sampledAt, players,
+	)
+	return err
+}
+
+func (r *playerCountRepository) GetHistory(appID int, from, to time.Time, bucket time.Duration) ([]models.PlayerCountBucket, error) {
+	var rows []struct {
+		Start   time.Time `db:"bucket_start"`
+		Min     int       `db:"min_players"`
+		Max     int       `db:"max_players"`
+		Avg     float64   `db:"avg_players"`
+		Samples int       `db:"samples"`
+	}
+	err := r.db.Select(&rows,
+		`SELECT to_timestamp(floor(extract(epoch FROM sampled_at) / $4) * $4) AS bucket_start,
+		        MIN(players) AS min_players,
+		        MAX(players) AS max_players,
+		        AVG(players)::float8 AS avg_players,
+		        COUNT(*) AS samples
+		 FROM player_counts
+		 WHERE app_id = $1 AND sampled_at >= $2 AND sampled_at < $3
+		 GROUP BY bucket_start
+		 ORDER BY bucket_start`,
+		appID, from, to, int64(bucket/time.Second),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]models.PlayerCountBucket, 0, len(rows))
+	for _, row := range rows {
+		buckets = append(buckets, models.PlayerCountBucket{
+			Start:   row.Start.UTC(),
+			Min:     row.Min,
+			Max:     row.Max,
+			Avg:     row.Avg,
+			Samples: row.Samples,
+		})
+	}
+	return buckets, nil
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type QuotaRepository interface {
+	// Reserve counts one call to endpoint with apiKey on day unless budget
+	// calls have already been made with that key that day, reporting whether
+	// the call was counted. A budget of 0 or less never refuses.
+	Reserve(ctx context.Context, day time.Time, apiKey, endpoint string, budget int) (bool, error)
+	// GetDailyUsage returns the calls made on day, by API key and endpoint.
+	GetDailyUsage(ctx context.Context, day time.Time) (map[string]map[string]int, error)
+}
+
+type quotaRepository struct {
+	db *sqlx.DB
+}
+
+func NewQuotaRepository(db *sqlx.DB) QuotaRepository {
+	return &quotaRepository{db: db}
+}
+
+// reserveQuery checks and increments the key's daily total in one statement:
+// the upsert's row lock serialises concurrent callers, and its WHERE clause
+// leaves the row untouched, returning nothing, once the budget is spent. The
+// per-endpoint breakdown is only bumped when the total was.
+const reserveQuery = `
+WITH reserved AS (
+	INSERT INTO api_quota_daily (day, api_key, calls) VALUES ($1, $2, 1)
+	ON CONFLICT (day, api_key) DO UPDATE SET calls = api_quota_daily.calls + 1
+	WHERE $4 <= 0 OR api_quota_daily.calls < $4
+	RETURNING calls
+), breakdown AS (
+	INSERT INTO api_quota (day, api_key, endpoint, calls) SELECT $1, $2, $3, 1 FROM reserved
+	ON CONFLICT (day, api_key, endpoint) DO UPDATE SET calls = api_quota.calls + 1
+)
+SELECT calls FROM reserved`
+
+func (r *quotaRepository) Reserve(ctx context.Context, day time.Time, apiKey, endpoint string, budget int) (bool, error) {
+	var calls int
+	err := r.db.GetContext(ctx, &calls, reserveQuery, day, apiKey, endpoint, budget)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *quotaRepository) GetDailyUsage(ctx context.Context, day time.Time) (map[string]map[string]int, error) {
+	var rows []struct {
+		APIKey   string `db:"api_key"`
+		Endpoint string `db:"endpoint"`
+		Calls    int    `db:"calls"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, `SELECT api_key, endpoint, calls FROM api_quota WHERE day = $1`, day); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]map[string]int)
+	for _, row := range rows {
+		if usage[row.APIKey] == nil {
+			usage[row.APIKey] = make(map[string]int)
+		}
+		usage[row.APIKey][row.Endpoint] = row.Calls
+	}
+	return usage, nil
+}
@@ -9,7 +9,9 @@ import (
 )
 
 type SteamRepository interface {
-	SaveRequestHistory(endpoint string, params map[string]interface{}, success bool, errorMessage string, duration time.Duration) error
+	// SaveRequestHistory records one service call. apiKey holds the KeyIDs
+	// ids of the Steam API keys that served it, empty for cache hits.
+	SaveRequestHistory(endpoint string, params map[string]interface{}, success bool, errorMessage string, duration time.Duration, apiKey string) error
 }
 
 type steamRepository struct {
@@ -20,14 +22,14 @@ func NewSteamRepository(db *sqlx.DB) SteamRepository {
 	return &steamRepository{db: db}
 }
 
-func (r *steamRepository) SaveRequestHistory(endpoint string, params map[string]interface{}, success bool, errorMessage string, duration time.Duration) error {
+func (r *steamRepository) SaveRequestHistory(endpoint string, params map[string]interface{}, success bool, errorMessage string, duration time.Duration, apiKey string) error {
 	jsonParams, err := json.Marshal(params)
 	if err != nil {
 		return fmt.Errorf("failed to marshal params: %w", err)
 	}
 	_, err = r.db.Exec(
-		`INSERT INTO request_history (endpoint, params, success, error_message, response_time_ms) VALUES ($1, $2, $3, $4, $5)`,
-		endpoint, jsonParams, success, errorMessage, duration,
+		`INSERT INTO request_history (endpoint, params, success, error_message, response_time_ms, api_key) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))`,
+		endpoint, jsonParams, success, errorMessage, duration, apiKey,
 	)
 	if err != nil {
 		return err
@@ -1,31 +1,39 @@
 package server
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/Uranury/RBK_fetchAPI/config"
+	"github.com/Uranury/RBK_fetchAPI/internal/cache"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
 	"github.com/Uranury/RBK_fetchAPI/internal/db"
 	"github.com/Uranury/RBK_fetchAPI/internal/handlers"
 	"github.com/Uranury/RBK_fetchAPI/internal/repositories"
 	"github.com/Uranury/RBK_fetchAPI/internal/services"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
-	"github.com/redis/go-redis/v9"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"golang.org/x/time/rate"
 )
 
 type Server struct {
-	router      *gin.Engine
-	cfg         *config.Config
-	db          *sqlx.DB
-	redisClient *redis.Client
-	userHandler *handlers.UserHandler
+	router        *gin.Engine
+	cfg           *config.Config
+	db            *sqlx.DB
+	cache         cache.Cache
+	userHandler   *handlers.UserHandler
+	appHandler    *handlers.AppHandler
+	adminHandler  *handlers.AdminHandler
+	healthHandler *handlers.HealthHandler
+
+	playerCountService *services.PlayerCountService
 }
 
-func NewServer(cfg *config.Config, redisClient *redis.Client) (*Server, error) {
+func NewServer(cfg *config.Config, appCache cache.Cache) (*Server, error) {
 	Database, err := db.InitDB("postgres", cfg.DB_URL, "internal/db/migrations")
 	if err != nil {
 		return nil, err
@@ -35,16 +43,59 @@ func NewServer(cfg *config.Config, redisClient *redis.Client) (*Server, error) {
 		Timeout: time.Second * 10,
 	}
 
+	quotaRepo := repositories.NewQuotaRepository(Database)
+	keys := make(map[string]string, len(cfg.SteamAPIKeys))
+	for _, key := range cfg.SteamAPIKeys {
+		keys[clients.KeyID(key)] = clients.MaskKey(key)
+	}
+	quotaService := services.NewQuotaService(quotaRepo, cfg.SteamDailyBudget, keys)
+
+	breakers := clients.NewBreakers(cfg.SteamBreakerThreshold, cfg.SteamBreakerCooldown)
+	steamClient := clients.NewSteamClient(cfg.SteamAPIKeys, cfg.SteamAPIURL, &httpClient, clients.Options{
+		Limiter: rate.NewLimiter(rate.Limit(cfg.SteamRateLimit), cfg.SteamRateBurst),
+		Quota:   quotaService,
+		Retry: clients.RetryPolicy{
+			MaxAttempts: cfg.SteamRetryAttempts,
+			BaseDelay:   cfg.SteamRetryBaseDelay,
+			MaxDelay:    cfg.SteamRetryMaxDelay,
+		},
+		Breakers:    breakers,
+		KeyCooldown: cfg.SteamKeyCooldown,
+	})
+	storeClient := clients.NewStoreClient(cfg.SteamStoreURL, &httpClient, clients.Options{
+		Limiter: rate.NewLimiter(rate.Limit(cfg.SteamStoreRateLimit), cfg.SteamStoreRateBurst),
+		Retry: clients.RetryPolicy{
+			MaxAttempts: cfg.SteamRetryAttempts,
+			BaseDelay:   cfg.SteamRetryBaseDelay,
+			MaxDelay:    cfg.SteamRetryMaxDelay,
+		},
+		Breakers: breakers,
+	})
 	steamRepo := repositories.NewSteamRepository(Database)
-	steamService := services.NewSteamService(cfg.SteamAPIKey, redisClient, steamRepo, &httpClient)
+	steamService := services.NewSteamService(steamClient, storeClient, appCache, steamRepo, services.Options{
+		DegradeMissingRarity:          cfg.DegradeMissingRarity,
+		AchievementSummaryConcurrency: cfg.AchievementSummaryConcurrency,
+		AchievementSummaryBudget:      cfg.AchievementSummaryBudget,
+		CachePolicies:                 cfg.CachePolicies,
+	})
+	playerCountRepo := repositories.NewPlayerCountRepository(Database)
+	playerCountService := services.NewPlayerCountService(steamService, playerCountRepo)
 	userHandler := handlers.NewUserHandler(steamService)
+	appHandler := handlers.NewAppHandler(steamService, playerCountService)
+	adminHandler := handlers.NewAdminHandler(quotaService)
+	healthHandler := handlers.NewHealthHandler(breakers)
 
 	server := &Server{
-		router:      gin.Default(),
-		cfg:         cfg,
-		db:          Database,
-		redisClient: redisClient,
-		userHandler: userHandler,
+		router:        gin.Default(),
+		cfg:           cfg,
+		db:            Database,
+		cache:         appCache,
+		userHandler:   userHandler,
+		appHandler:    appHandler,
+		adminHandler:  adminHandler,
+		healthHandler: healthHandler,
+
+		playerCountService: playerCountService,
 	}
 
 	server.setupRoutes()
@@ -52,6 +103,10 @@ func NewServer(cfg *config.Config, redisClient *redis.Client) (*Server, error) {
 }
 
 func (s *Server) Start() error {
+	if len(s.cfg.PlayerSamplerAppIDs) > 0 {
+		go s.playerCountService.RunSampler(context.Background(), s.cfg.PlayerSamplerAppIDs, s.cfg.PlayerSamplerInterval)
+	}
+
 	log.Printf("Listening on port %s...", s.cfg.ListenAddr)
 	return s.router.Run(s.cfg.ListenAddr)
 }
@@ -62,8 +117,30 @@ func (s *Server) setupRoutes() {
 	s.router.GET("/ping", func(c *gin.Context) {
 		c.JSON(200, gin.H{"msg": "pong"})
 	})
+	s.router.GET("/health", s.healthHandler.GetHealth)
 	s.router.GET("/steam_id", s.userHandler.GetVanityProfile)
+	s.router.GET("/steam_id/convert", s.userHandler.ConvertSteamID)
 	s.router.GET("/games", s.userHandler.GetOwnedGames)
+	s.router.GET("/games/stats", s.userHandler.GetLibraryStats)
+	s.router.GET("/recent", s.userHandler.GetRecentlyPlayedGames)
 	s.router.GET("/summary", s.userHandler.GetUserSummary)
+	s.router.GET("/summaries", s.userHandler.GetUserSummaries)
+	s.router.POST("/summaries", s.userHandler.GetUserSummaries)
+	s.router.GET("/level", s.userHandler.GetSteamLevel)
+	s.router.GET("/badges", s.userHandler.GetBadges)
+	s.router.GET("/bans", s.userHandler.GetUserBans)
+	s.router.GET("/friends", s.userHandler.GetFriendList)
 	s.router.GET("/achievements", s.userHandler.GetUserAchievements)
+	s.router.GET("/achievements/summary", s.userHandler.GetAchievementSummary)
+	s.router.GET("/stats", s.userHandler.GetUserStats)
+
+	apps := s.router.Group("/apps")
+	apps.GET("/:appID", s.appHandler.GetAppDetails)
+	apps.GET("/:appID/news", s.appHandler.GetAppNews)
+	apps.GET("/:appID/players", s.appHandler.GetCurrentPlayers)
+	apps.GET("/:appID/players/history", s.appHandler.GetPlayerHistory)
+	apps.GET("/:appID/achievements/global", s.appHandler.GetGlobalAchievements)
+
+	admin := s.router.Group("/admin")
+	admin.GET("/quota", s.adminHandler.GetQuota)
 }
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultAchievementSummaryConcurrency = 4
+	defaultAchievementSummaryBudget      = 20 * time.Second
+
+	// rarestUnlockedCount is how many achievements AchievementSummary ranks.
+	rarestUnlockedCount = 10
+)
+
+// GetAchievementSummary returns the user's achievement progress across every
+// owned game with community stats. Games are fetched through the same cache
+// as GetPlayerAchievements, a few at a time, for at most the configured
+// budget. Games still missing by then make the summary partial: it is
+// cached only briefly, under its own resource, so requests in that window
+// share it instead of fanning out again, and the next build picks up where
+// this one stopped, since the fetches it started keep running and cache
+// their results. A game that keeps failing therefore costs one fan-out per
+// partial window rather than one per request.
+func (s *SteamService) GetAchievementSummary(ctx context.Context, steamID string) (*models.AchievementSummary, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/achievements/summary:GetAchievementSummary"
+	params := map[string]interface{}{"steamID": steamID}
+
+	cacheKey := fmt.Sprintf("achievement_summary:%s", steamID)
+	partialKey := fmt.Sprintf("partial_achievement_summary:%s", steamID)
+	summary, err := getOrFetch(ctx, s, resourceAchievementSummary, cacheKey, func(ctx context.Context) (*models.AchievementSummary, error) {
+		if entry := readCached[models.AchievementSummary](ctx, s, partialKey); entry != nil && entry.fresh(s.policy(resourcePartialAchievementSummary)) {
+			return entry.Value, nil
+		}
+		summary, err := s.buildAchievementSummary(ctx, steamID)
+		if err == nil && summary.Partial() {
+			writeCached(ctx, s, resourcePartialAchievementSummary, partialKey, summary)
+		}
+		return summary, err
+	})
+	s.logResult(ctx, endpoint, params, start, err)
+	return summary, err
+}
+
+func (s *SteamService) buildAchievementSummary(ctx context.Context, steamID string) (*models.AchievementSummary, error) {
+	owned, err := s.GetOwnedGames(ctx, steamID)
+	if err != nil {
+		return nil, err
+	}
+
+	var games []models.OwnedGame
+	for _, game := range owned.Response.Games {
+		if game.HasCommunityVisibleStats {
+			games = append(games, game)
+		}
+	}
+
+	budget := s.options.AchievementSummaryBudget
+	if budget <= 0 {
+		budget = defaultAchievementSummaryBudget
+	}
+	concurrency := s.options.AchievementSummaryConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultAchievementSummaryConcurrency
+	}
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	// Each goroutine writes only its own slot, so results keep library order
+	results := make([]*models.PlayerAchievements, len(games))
+	errs := make([]error, len(games))
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, game := range games {
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return nil
+			}
+			appID := strconv.Itoa(game.AppID)
+			cacheKey := fmt.Sprintf("player_achievements:%s:game:%s", steamID, appID)
+			results[i], errs[i] = getOrFetch(ctx, s, resourcePlayerAchievements, cacheKey, func(ctx context.Context) (*models.PlayerAchievements, error) {
+				return s.buildPlayerAchievements(ctx, steamID, appID)
+			})
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	summary := &models.AchievementSummary{
+		SteamID:        steamID,
+		Games:          []models.GameCompletion{},
+		RarestUnlocked: []models.RareAchievement{},
+	}
+	var (
+		rare              []models.RareAchievement
+		startedGames      int
+		startedPercentage float64
+	)
+	for i, game := range games {
+		switch {
+		case errs[i] != nil && isUpstreamFailure(errs[i]):
+			summary.PendingGames++
+			continue
+		case errs[i] != nil:
+			log.Printf("skipping appID %d in achievement summary of %s: %v", game.AppID, steamID, errs[i])
+			summary.SkippedGames++
+			continue
+		case len(results[i].Achievements) == 0:
+			summary.SkippedGames++
+			continue
+		}
+
+		completion := models.GameCompletion{
+			AppID: game.AppID,
+			Name:  game.Name,
+			Total: len(results[i].Achievements),
+		}
+		for _, achievement := range results[i].Achievements {
+			if !achievement.Achieved {
+				continue
+			}
+			completion.Unlocked++
+			// A zero rarity means Steam has no global percentage for the
+			// achievement, not that nobody else unlocked it
+			if !results[i].RarityUnavailable && achievement.Rarity > 0 {
+				rare = append(rare, models.RareAchievement{AppID: game.AppID, GameName: game.Name, Achievement: achievement})
+			}
+		}
+		completion.Percentage = percentage(completion.Unlocked, completion.Total)
+
+		summary.Games = append(summary.Games, completion)
+		summary.Unlocked += completion.Unlocked
+		summary.Total += completion.Total
+		if completion.Unlocked == completion.Total {
+			summary.PerfectGames++
+		}
+		if completion.Unlocked > 0 {
+			startedGames++
+			startedPercentage += completion.Percentage
+		}
+	}
+
+	summary.Complete = summary.PendingGames == 0
+	summary.CompletionRate = percentage(summary.Unlocked, summary.Total)
+	if startedGames > 0 {
+		summary.AverageCompletion = startedPercentage / float64(startedGames)
+	}
+
+	slices.SortStableFunc(summary.Games, func(a, b models.GameCompletion) int {
+		return cmp.Or(cmp.Compare(b.Percentage, a.Percentage), cmp.Compare(a.AppID, b.AppID))
+	})
+	slices.SortStableFunc(rare, func(a, b models.RareAchievement) int {
+		return cmp.Or(cmp.Compare(a.Rarity, b.Rarity), a.UnlockTime.Compare(b.UnlockTime))
+	})
+	summary.RarestUnlocked = append(summary.RarestUnlocked, rare[:min(rarestUnlockedCount, len(rare))]...)
+	return summary, nil
+}
+
+// percentage returns part over whole on a 0-100 scale, or 0 when whole is 0.
+func percentage(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole) * 100
+}
@@ -0,0 +1,153 @@
+package services_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/cache"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/Uranury/RBK_fetchAPI/internal/services"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) useAchievementLibrary() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.OwnedGames = &models.OwnedGamesResponse{}
+	suite.steamClient.OwnedGames.Response.Games = []models.OwnedGame{
+		{AppID: 10, Name: "Half done", HasCommunityVisibleStats: true},
+		{AppID: 20, Name: "Perfect", HasCommunityVisibleStats: true},
+		{AppID: 30, Name: "No stats"},
+		{AppID: 40, Name: "Flaky", HasCommunityVisibleStats: true},
+		{AppID: 50, Name: "No achievements", HasCommunityVisibleStats: true},
+	}
+
+	perfect := &models.PlayerAchievementsResponse{}
+	suite.Require().NoError(json.Unmarshal([]byte(`{"playerstats": {
+		"steamID": "76561197960434622", "gameName": "Perfect", "success": true,
+		"achievements": [
+			{"apiname": "ACH_WIN", "achieved": 1, "unlocktime": 1500000000},
+			{"apiname": "ACH_LOSE", "achieved": 1, "unlocktime": 1500000001}
+		]}}`), perfect))
+	suite.steamClient.AchievementsByApp = map[string]*models.PlayerAchievementsResponse{"20": perfect}
+	suite.steamClient.AchievementErrs = map[string]error{
+		"40": apperrors.NewAPIError(502, "Bad Gateway"),
+		"50": apperrors.NewAPIError(400, "Requested app has no stats"),
+	}
+}
+
+// partialSummaryPolicy keeps partial summaries for long enough to be shared
+// by back-to-back requests, and no longer.
+var partialSummaryPolicy = map[string]cache.Policy{"partial_achievement_summary": {Fresh: 20 * time.Millisecond}}
+
+func (suite *SteamServiceTestSuite) TestAchievementSummary() {
+	suite.useAchievementLibrary()
+	suite.service = services.NewSteamService(suite.steamClient, suite.steamClient, suite.cache, suite.repoMock, services.Options{
+		CachePolicies: partialSummaryPolicy,
+	})
+
+	summary, err := suite.service.GetAchievementSummary(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+
+	suite.Equal(3, summary.Unlocked)
+	suite.Equal(4, summary.Total)
+	suite.Equal(75.0, summary.CompletionRate)
+	suite.Equal(75.0, summary.AverageCompletion)
+	suite.Equal(1, summary.PerfectGames)
+	suite.Equal([]models.GameCompletion{
+		{AppID: 20, Name: "Perfect", Unlocked: 2, Total: 2, Percentage: 100},
+		{AppID: 10, Name: "Half done", Unlocked: 1, Total: 2, Percentage: 50},
+	}, summary.Games)
+	suite.False(summary.Complete)
+	suite.Equal(1, summary.PendingGames)
+	suite.Equal(1, summary.SkippedGames)
+
+	// Rarest first; equal rarities go by unlock time
+	suite.Require().Len(summary.RarestUnlocked, 3)
+	suite.Equal(20, summary.RarestUnlocked[0].AppID)
+	suite.Equal("ACH_WIN", summary.RarestUnlocked[0].Name)
+	suite.Equal(10, summary.RarestUnlocked[1].AppID)
+	suite.Equal("ACH_LOSE", summary.RarestUnlocked[2].Name)
+	suite.Equal(4, suite.steamClient.Calls("GetPlayerAchievements"))
+
+	// The partial summary is shared briefly instead of fanning out again
+	summary, err = suite.service.GetAchievementSummary(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.False(summary.Complete)
+	suite.Equal(4, suite.steamClient.Calls("GetPlayerAchievements"))
+
+	// Once it expires only the missing game is fetched again
+	time.Sleep(25 * time.Millisecond)
+	delete(suite.steamClient.AchievementErrs, "40")
+	summary, err = suite.service.GetAchievementSummary(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.True(summary.Complete)
+	suite.Len(summary.Games, 3)
+	suite.Equal(6, suite.steamClient.Calls("GetPlayerAchievements"))
+
+	// Complete summaries are cached
+	_, err = suite.service.GetAchievementSummary(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.Equal(6, suite.steamClient.Calls("GetPlayerAchievements"))
+}
+
+func (suite *SteamServiceTestSuite) TestAchievementSummaryResumesAfterBudget() {
+	suite.useAchievementLibrary()
+	suite.steamClient.AchievementErrs = nil
+	service := services.NewSteamService(suite.steamClient, suite.steamClient, suite.cache, suite.repoMock, services.Options{
+		AchievementSummaryBudget:      50 * time.Millisecond,
+		AchievementSummaryConcurrency: 2,
+		CachePolicies:                 partialSummaryPolicy,
+	})
+	_, err := service.GetOwnedGames(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+
+	// Steam hangs past the budget
+	suite.steamClient.Gate = make(chan struct{})
+	summary, err := service.GetAchievementSummary(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.False(summary.Complete)
+	suite.Equal(4, summary.PendingGames)
+	suite.Empty(summary.Games)
+
+	// The fetches that were started finish in the background and are
+	// cached, so a later request makes progress
+	close(suite.steamClient.Gate)
+	suite.Eventually(func() bool {
+		summary, err = service.GetAchievementSummary(suite.testContext, "76561197960434622")
+		return err == nil && summary.Complete
+	}, 5*time.Second, 20*time.Millisecond)
+	suite.Len(summary.Games, 4)
+}
+
+func (suite *SteamServiceTestSuite) TestAchievementSummarySkipsUnknownRarity() {
+	suite.useAchievementLibrary()
+	suite.steamClient.AchievementErrs = nil
+	// ACH_OLD is orphaned, so Steam has no global percentage for it
+	suite.Require().NoError(json.Unmarshal([]byte(`{"playerstats": {
+		"steamID": "76561197960434622", "gameName": "Perfect", "success": true,
+		"achievements": [
+			{"apiname": "ACH_WIN", "achieved": 1, "unlocktime": 1500000000},
+			{"apiname": "ACH_OLD", "achieved": 1, "unlocktime": 1400000000}
+		]}}`), suite.steamClient.AchievementsByApp["20"]))
+
+	summary, err := suite.service.GetAchievementSummary(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+
+	suite.Equal(2, suite.gameCompletion(summary, 20).Unlocked)
+	for _, achievement := range summary.RarestUnlocked {
+		suite.NotEqual("ACH_OLD", achievement.Name)
+		suite.Positive(achievement.Rarity)
+	}
+	suite.Equal("ACH_WIN", summary.RarestUnlocked[0].Name)
+}
+
+func (suite *SteamServiceTestSuite) gameCompletion(summary *models.AchievementSummary, appID int) models.GameCompletion {
+	for _, game := range summary.Games {
+		if game.AppID == appID {
+			return game
+		}
+	}
+	suite.FailNow("game missing from summary", "appID %d", appID)
+	return models.GameCompletion{}
+}
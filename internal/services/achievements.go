@@ -2,56 +2,75 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
-	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
 	"github.com/Uranury/RBK_fetchAPI/internal/models"
-)
-
-const (
-	fetchGameSchemaTemplate                   = "http://api.steampowered.com/ISteamUserStats/GetSchemaForGame/v2/?key=%s&appid=%s"
-	fetchPlayerAchievementsTemplate           = "http://api.steampowered.com/ISteamUserStats/GetPlayerAchievements/v0001/?appid=%s&key=%s&steamid=%s"
-	fetchGlobalAchievementPercentagesTemplate = "http://api.steampowered.com/ISteamUserStats/GetGlobalAchievementPercentagesForApp/v0002/?gameid=%s"
+	"golang.org/x/sync/errgroup"
 )
 
 func (s *SteamService) GetPlayerAchievements(ctx context.Context, steamID, appID string) (*models.PlayerAchievements, *apperrors.APIError) {
 	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
 	endpoint := "/achievements:GetPlayerAchievements"
 	params := map[string]interface{}{"steamID": steamID, "appID": appID}
 
 	cacheKey := fmt.Sprintf("player_achievements:%s:game:%s", steamID, appID)
-
-	cached, err := s.Cache.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var achievements models.PlayerAchievements
-		if err := json.Unmarshal([]byte(cached), &achievements); err == nil {
-			s.logRequest(endpoint, params, true, "", time.Since(start))
-			return &achievements, nil
-		}
-	}
-
-	playerAchievements, apiError := s.fetchPlayerAchievements(ctx, steamID, appID)
-	if apiError != nil {
-		s.logRequest(endpoint, params, false, apiError.Message, time.Since(start))
+	result, err := getOrFetch(ctx, s, resourcePlayerAchievements, cacheKey, func(ctx context.Context) (*models.PlayerAchievements, error) {
+		return s.buildPlayerAchievements(ctx, steamID, appID)
+	})
+	if err != nil {
+		apiError := apperrors.AsAPIError(err)
+		s.logRequest(ctx, endpoint, params, false, apiError.Message, time.Since(start))
 		return nil, apiError
 	}
 
-	gameSchema, apiError := s.fetchGameSchema(ctx, appID)
-	if apiError != nil {
-		s.logRequest(endpoint, params, false, apiError.Message, time.Since(start))
-		return nil, apiError
-	}
+	s.logRequest(ctx, endpoint, params, true, "", time.Since(start))
+	return result, nil
+}
 
-	// Get global achievement percentages for rarity
-	globalPercentages, apiError := s.fetchGlobalAchievementPercentages(ctx, appID)
-	if apiError != nil {
-		s.logRequest(endpoint, params, false, apiError.Message, time.Since(start))
-		return nil, apiError
+func (s *SteamService) buildPlayerAchievements(ctx context.Context, steamID, appID string) (*models.PlayerAchievements, error) {
+	var (
+		playerAchievements *models.PlayerAchievementsResponse
+		gameSchema         *models.GameSchemaResponse
+		globalPercentages  *models.GlobalAchievementPercentagesResponse
+	)
+
+	// The three lookups are independent; the first fatal error cancels the rest
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var apiError *apperrors.APIError
+		if playerAchievements, apiError = s.fetchPlayerAchievements(gctx, steamID, appID); apiError != nil {
+			return apiError
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var apiError *apperrors.APIError
+		if gameSchema, apiError = s.fetchGameSchema(gctx, appID); apiError != nil {
+			return apiError
+		}
+		return nil
+	})
+	g.Go(func() error {
+		// Get global achievement percentages for rarity
+		percentages, apiError := s.fetchGlobalAchievementPercentages(gctx, appID)
+		if apiError != nil {
+			if !s.options.DegradeMissingRarity {
+				return apiError
+			}
+			log.Printf("global achievement percentages unavailable for appID %s, rarity set to 0: %v", appID, apiError)
+			return nil
+		}
+		globalPercentages = percentages
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
 	// Create maps for quick lookup
@@ -63,189 +82,99 @@ func (s *SteamService) GetPlayerAchievements(ctx context.Context, steamID, appID
 			Description: ach.Description,
 			Icon:        ach.Icon,
 			IconGray:    ach.IconGray,
+			Hidden:      ach.Hidden == 1,
 		}
 	}
 
-	percentageMap := make(map[string]float64)
-	for _, perc := range globalPercentages.AchievementPercentages.Achievements {
-		if percentage, err := strconv.ParseFloat(perc.Percent, 64); err == nil {
-			percentageMap[perc.Name] = percentage
-		}
-	}
+	percentageMap := achievementPercentages(globalPercentages)
 
 	// Combine player achievements with schema data and rarity
 	result := &models.PlayerAchievements{
-		SteamID:  playerAchievements.PlayerStats.SteamID,
-		GameName: playerAchievements.PlayerStats.GameName,
+		SteamID:           playerAchievements.PlayerStats.SteamID,
+		GameName:          playerAchievements.PlayerStats.GameName,
+		RarityUnavailable: globalPercentages == nil,
 	}
 
 	for _, playerAch := range playerAchievements.PlayerStats.Achievements {
-		if schemaAch, exists := schemaMap[playerAch.APIName]; exists {
-			achievement := models.Achievement{
-				Name:        schemaAch.Name,
-				DisplayName: schemaAch.DisplayName,
-				Description: schemaAch.Description,
-				Achieved:    playerAch.Achieved == 1,
-				Icon:        schemaAch.Icon,
-				IconGray:    schemaAch.IconGray,
-				Rarity:      percentageMap[playerAch.APIName], // Add rarity percentage
-			}
-
-			// Format unlock time correctly (convert from Unix timestamp)
-			if playerAch.Achieved == 1 && playerAch.UnlockTime > 0 {
-				achievement.UnlockTime = time.Unix(playerAch.UnlockTime, 0)
+		achievement, exists := schemaMap[playerAch.APIName]
+		if !exists {
+			// Achievements removed from (or not yet in) the schema are still
+			// reported, with only what the player data tells us
+			achievement = models.Achievement{
+				Name:        playerAch.APIName,
+				DisplayName: playerAch.APIName,
+				Orphaned:    true,
 			}
-
-			result.Achievements = append(result.Achievements, achievement)
 		}
-	}
+		achievement.Achieved = playerAch.Achieved == 1
+		achievement.Rarity = percentageMap[playerAch.APIName] // Add rarity percentage
 
-	bytes, err := json.Marshal(result)
-	if err == nil {
-		if err := s.Cache.Set(ctx, cacheKey, bytes, time.Minute*5).Err(); err != nil {
-			log.Printf("failed to cache GetAchievements: %v", err)
+		// Format unlock time correctly (convert from Unix timestamp)
+		if playerAch.Achieved == 1 && playerAch.UnlockTime > 0 {
+			achievement.UnlockTime = time.Unix(playerAch.UnlockTime, 0)
 		}
+
+		result.Achievements = append(result.Achievements, achievement)
 	}
 
-	s.logRequest(endpoint, params, true, "", time.Since(start))
 	return result, nil
 }
 
 func (s *SteamService) fetchPlayerAchievements(ctx context.Context, steamID, appID string) (*models.PlayerAchievementsResponse, *apperrors.APIError) {
 	cacheKey := fmt.Sprintf("fetched_player_achievements:%s:game:%s", steamID, appID)
 
-	cached, err := s.Cache.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var achievements models.PlayerAchievementsResponse
-		if err := json.Unmarshal([]byte(cached), &achievements); err == nil {
-			return &achievements, nil
+	result, err := getOrFetch(ctx, s, resourceFetchedPlayerAchievements, cacheKey, func(ctx context.Context) (*models.PlayerAchievementsResponse, error) {
+		result, err := s.steamClient.GetPlayerAchievements(ctx, steamID, appID)
+		if err != nil {
+			return nil, err
 		}
-		log.Printf("failed to unmarshal cached Achievements: %v", err)
-	}
-
-	url := fmt.Sprintf(fetchPlayerAchievementsTemplate, appID, s.APIKey, steamID)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, apperrors.WrapAPIError(500, err, "fetchPlayerAchievements request creation failed")
-	}
-
-	resp, err := s.HTTPClient.Do(req)
-	if err != nil {
-		return nil, apperrors.WrapAPIError(500, err, "fetchPlayerAchievements request failed")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("steam API responded with status: %s", resp.Status)
-		return nil, apperrors.WrapAPIError(resp.StatusCode, err, "")
-	}
-
-	var result models.PlayerAchievementsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, apperrors.WrapAPIError(500, err, "fetchPlayerAchievements failed to decode JSON")
-	}
-
-	if !result.PlayerStats.Success {
-		return nil, apperrors.WrapAPIError(409, err, "fetchPlayerAchievements, invalid appID or private profile")
-	}
-
-	bytes, err := json.Marshal(result)
-	if err == nil {
-		if err := s.Cache.Set(ctx, cacheKey, bytes, 5*time.Minute).Err(); err != nil {
-			log.Printf("failed to cache fetchedAchievements: %v", err)
+		if !result.PlayerStats.Success {
+			err := fmt.Errorf("steam reported success=false for steamID %s, appID %s", steamID, appID)
+			return nil, apperrors.WrapAPIError(409, err, "fetchPlayerAchievements, invalid appID or private profile")
 		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, apperrors.AsAPIError(err)
 	}
-
-	return &result, nil
+	return result, nil
 }
 
 func (s *SteamService) fetchGameSchema(ctx context.Context, appID string) (*models.GameSchemaResponse, *apperrors.APIError) {
 	cacheKey := fmt.Sprintf("game_schema:%s", appID)
 
-	cached, err := s.Cache.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var schema models.GameSchemaResponse
-		if err := json.Unmarshal([]byte(cached), &schema); err == nil {
-			return &schema, nil
-		}
-		log.Printf("Failed to get cached GameSchema: %v", err)
-	}
-
-	url := fmt.Sprintf(fetchGameSchemaTemplate, s.APIKey, appID)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	result, err := getOrFetch(ctx, s, resourceGameSchema, cacheKey, func(ctx context.Context) (*models.GameSchemaResponse, error) {
+		return s.steamClient.GetSchemaForGame(ctx, appID)
+	})
 	if err != nil {
-		return nil, apperrors.WrapAPIError(500, err, "fetchGameSchema request creation failed")
-	}
-
-	resp, err := s.HTTPClient.Do(req)
-	if err != nil {
-		return nil, apperrors.WrapAPIError(500, err, "fetchGameSchema request failed")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("API request failed with status: %d", resp.StatusCode)
-		return nil, apperrors.WrapAPIError(resp.StatusCode, err, "")
-	}
-
-	var result models.GameSchemaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, apperrors.WrapAPIError(500, err, "fetchGameSchema failed to decode JSON")
-	}
-
-	bytes, err := json.Marshal(result)
-	if err == nil {
-		if err := s.Cache.Set(ctx, cacheKey, bytes, time.Hour*336).Err(); err != nil {
-			log.Printf("failed to cache fetchedGameSchemas: %v", err)
-		}
+		return nil, apperrors.AsAPIError(err)
 	}
-
-	return &result, nil
+	return result, nil
 }
 
 func (s *SteamService) fetchGlobalAchievementPercentages(ctx context.Context, appID string) (*models.GlobalAchievementPercentagesResponse, *apperrors.APIError) {
 	cacheKey := fmt.Sprintf("global_achievement_percentages:%s", appID)
 
-	cached, err := s.Cache.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var percentages models.GlobalAchievementPercentagesResponse
-		if err := json.Unmarshal([]byte(cached), &percentages); err == nil {
-			return &percentages, nil
-		}
-		log.Printf("failed to get cached achievement percentages: %v", err)
-	}
-
-	url := fmt.Sprintf(fetchGlobalAchievementPercentagesTemplate, appID)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, apperrors.WrapAPIError(500, err, "fetchGlobalAchievementPercentages request creation failed")
-	}
-
-	resp, err := s.HTTPClient.Do(req)
+	result, err := getOrFetch(ctx, s, resourceGlobalAchievementPercentages, cacheKey, func(ctx context.Context) (*models.GlobalAchievementPercentagesResponse, error) {
+		return s.steamClient.GetGlobalAchievementPercentages(ctx, appID)
+	})
 	if err != nil {
-		return nil, apperrors.WrapAPIError(500, err, "fetchGlobalAchievementPercentages request failed")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("API request failed with status: %d", resp.StatusCode)
-		return nil, apperrors.WrapAPIError(resp.StatusCode, err, "")
+		return nil, apperrors.AsAPIError(err)
 	}
+	return result, nil
+}
 
-	var result models.GlobalAchievementPercentagesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, apperrors.WrapAPIError(500, err, "fetchGlobalAchievementPercentages failed to decode JSON")
+// achievementPercentages maps achievement API names to the percentage of
+// players who unlocked them. A nil response yields an empty map.
+func achievementPercentages(response *models.GlobalAchievementPercentagesResponse) map[string]float64 {
+	percentages := make(map[string]float64)
+	if response == nil {
+		return percentages
 	}
-
-	bytes, err := json.Marshal(result)
-	if err == nil {
-		if err := s.Cache.Set(ctx, cacheKey, bytes, time.Hour*24).Err(); err != nil {
-			log.Printf("failed to cache GlobalAchievementPercentages: %v", err)
+	for _, perc := range response.AchievementPercentages.Achievements {
+		if percentage, err := strconv.ParseFloat(perc.Percent, 64); err == nil {
+			percentages[perc.Name] = percentage
 		}
 	}
-
-	return &result, nil
+	return percentages
 }
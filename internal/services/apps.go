@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+)
+
+// GetAppDetails returns appID's store page with prices for countryCode
+// (e.g. "us") and text in language (e.g. "english"). Either may be empty to
+// use the store's defaults. Each app, country and language combination is
+// cached separately.
+func (s *SteamService) GetAppDetails(ctx context.Context, appID, countryCode, language string) (*models.App, error) {
+	start := time.Now()
+	endpoint := "/apps:GetAppDetails"
+	params := map[string]interface{}{"appID": appID, "cc": countryCode, "l": language}
+
+	countryCode, language = strings.ToLower(countryCode), strings.ToLower(language)
+	cacheKey := fmt.Sprintf("app_details:%s:%s:%s", appID, countryCode, language)
+	data, err := getOrFetch(ctx, s, resourceAppDetails, cacheKey, func(ctx context.Context) (*models.StoreAppData, error) {
+		response, err := s.storeClient.GetAppDetails(ctx, appID, countryCode, language)
+		if err != nil {
+			return nil, err
+		}
+		details, ok := (*response)[appID]
+		if !ok || !details.Success || details.Data == nil {
+			return nil, apperrors.NewAPIError(404, fmt.Sprintf("no store page for appID %s", appID))
+		}
+		return details.Data, nil
+	})
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return storeApp(data), nil
+}
+
+func storeApp(data *models.StoreAppData) *models.App {
+	app := &models.App{
+		AppID:            data.SteamAppID,
+		Type:             data.Type,
+		Name:             data.Name,
+		ShortDescription: data.ShortDescription,
+		HeaderImage:      data.HeaderImage,
+		Website:          data.Website,
+		Developers:       data.Developers,
+		Publishers:       data.Publishers,
+		Genres:           make([]string, 0, len(data.Genres)),
+		Categories:       make([]string, 0, len(data.Categories)),
+		ReleaseDate:      models.ReleaseDate{ComingSoon: data.ReleaseDate.ComingSoon, Date: data.ReleaseDate.Date},
+		Platforms:        models.Platforms(data.Platforms),
+		IsFree:           data.IsFree,
+	}
+	for _, genre := range data.Genres {
+		app.Genres = append(app.Genres, genre.Description)
+	}
+	for _, category := range data.Categories {
+		app.Categories = append(app.Categories, category.Description)
+	}
+	if data.Metacritic != nil {
+		app.Metacritic = &models.Metacritic{Score: data.Metacritic.Score, URL: data.Metacritic.URL}
+	}
+	if p := data.PriceOverview; p != nil {
+		app.Price = &models.Price{
+			Currency:         p.Currency,
+			Initial:          p.Initial,
+			Final:            p.Final,
+			DiscountPercent:  p.DiscountPercent,
+			InitialFormatted: p.InitialFormatted,
+			FinalFormatted:   p.FinalFormatted,
+		}
+	}
+	return app
+}
@@ -0,0 +1,50 @@
+package services_test
+
+import (
+	"encoding/json"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestAppDetailsAreMappedAndCachedPerLocale() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	data := &models.StoreAppData{}
+	suite.Require().NoError(json.Unmarshal([]byte(`{
+		"type": "game", "name": "Terraria", "steam_appid": 105600,
+		"developers": ["Re-Logic"], "publishers": ["Re-Logic"],
+		"genres": [{"id": "1", "description": "Action"}, {"id": "25", "description": "Adventure"}],
+		"categories": [{"id": 2, "description": "Single-player"}],
+		"release_date": {"coming_soon": false, "date": "16 May, 2011"},
+		"metacritic": {"score": 83, "url": "https://www.metacritic.com/game/pc/terraria"},
+		"price_overview": {"currency": "USD", "initial": 999, "final": 499, "discount_percent": 50,
+			"initial_formatted": "$9.99", "final_formatted": "$4.99"}
+	}`), data))
+	suite.steamClient.Apps = map[string]*models.StoreAppData{"105600": data}
+
+	app, err := suite.service.GetAppDetails(suite.testContext, "105600", "US", "english")
+	suite.Require().NoError(err)
+	suite.Equal("Terraria", app.Name)
+	suite.Equal([]string{"Action", "Adventure"}, app.Genres)
+	suite.Equal([]string{"Single-player"}, app.Categories)
+	suite.Equal(83, app.Metacritic.Score)
+	suite.Equal(&models.Price{Currency: "USD", Initial: 999, Final: 499, DiscountPercent: 50, InitialFormatted: "$9.99", FinalFormatted: "$4.99"}, app.Price)
+
+	// Country codes are case-insensitive; another country is a separate entry
+	_, err = suite.service.GetAppDetails(suite.testContext, "105600", "us", "english")
+	suite.Require().NoError(err)
+	_, err = suite.service.GetAppDetails(suite.testContext, "105600", "de", "english")
+	suite.Require().NoError(err)
+	suite.Equal(2, suite.steamClient.Calls("GetAppDetails"))
+}
+
+func (suite *SteamServiceTestSuite) TestUnknownAppIsNotFound() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := suite.service.GetAppDetails(suite.testContext, "1", "", "")
+
+	var apiErr *apperrors.APIError
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(404, apiErr.StatusCode)
+}
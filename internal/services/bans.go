@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+)
+
+// bansPerCall is the most steamids Steam accepts in one GetPlayerBans call.
+const bansPerCall = 100
+
+// GetPlayerBansBatch looks up the ban records of many players at once, the
+// same way GetPlayerSummariesBatch does: fresh bans:<id> cache entries are
+// served directly and the rest are fetched from Steam in chunks of 100.
+func (s *SteamService) GetPlayerBansBatch(ctx context.Context, steamIDs []string) (map[string]models.PlayerBansResult, error) {
+	return serveBatch(ctx, s, "/bans:GetPlayerBans", steamIDs, s.bansLookup(), func(r batchResult[models.PlayerBans]) models.PlayerBansResult {
+		return models.PlayerBansResult{Bans: r.Value, Error: r.Error}
+	})
+}
+
+// GetPlayerBans returns the ban record of a single player.
+func (s *SteamService) GetPlayerBans(ctx context.Context, steamID string) (*models.PlayerBans, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/summary:GetPlayerBans"
+	params := map[string]interface{}{"steam_id": steamID}
+
+	results, err := lookupBatch(ctx, s.bansLookup(), []string{steamID})
+	result := results[steamID]
+	if err == nil && result.Value == nil {
+		err = apperrors.NewAPIError(404, result.Error)
+	}
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+func (s *SteamService) bansLookup() batchLookup[models.PlayerBans] {
+	return batchLookup[models.PlayerBans]{
+		perCall: bansPerCall,
+		cached: func(ctx context.Context, id string) (*models.PlayerBans, bool) {
+			entry := readCached[models.PlayerBans](ctx, s, fmt.Sprintf("bans:%s", id))
+			if entry == nil {
+				return nil, false
+			}
+			return entry.Value, entry.fresh(s.policy(resourceBans))
+		},
+		fetch: s.fetchBansChunk,
+	}
+}
+
+// fetchBansChunk fetches up to 100 ban records and caches each one under its
+// own bans:<id> key.
+func (s *SteamService) fetchBansChunk(ctx context.Context, steamIDs []string) (map[string]*models.PlayerBans, error) {
+	response, err := s.steamClient.GetPlayerBans(ctx, steamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*models.PlayerBans, len(response.Players))
+	for _, p := range response.Players {
+		bans := &models.PlayerBans{
+			SteamID:          p.SteamID,
+			VACBanned:        p.VACBanned,
+			VACBans:          p.NumberOfVACBans,
+			GameBans:         p.NumberOfGameBans,
+			CommunityBanned:  p.CommunityBanned,
+			EconomyBan:       models.EconomyBan(p.EconomyBan),
+			DaysSinceLastBan: p.DaysSinceLastBan,
+		}
+		found[p.SteamID] = bans
+		writeCached(ctx, s, resourceBans, fmt.Sprintf("bans:%s", p.SteamID), bans)
+	}
+	return found, nil
+}
@@ -0,0 +1,35 @@
+package services_test
+
+import (
+	"encoding/json"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestBansBatchDecodesAndCaches() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.Bans = &models.PlayerBansResponse{}
+	suite.Require().NoError(json.Unmarshal([]byte(`{"players": [
+		{"SteamId": "76561197960287930", "VACBanned": true, "NumberOfVACBans": 2, "DaysSinceLastBan": 30, "EconomyBan": "probation"},
+		{"SteamId": "76561197960287931", "EconomyBan": "none"}
+	]}`), suite.steamClient.Bans))
+
+	results, err := suite.service.GetPlayerBansBatch(suite.testContext, []string{"76561197960287930", "76561197960287931", "76561198099999999"})
+	suite.Require().NoError(err)
+
+	suite.Len(results, 3)
+	banned := results["76561197960287930"].Bans
+	suite.Require().NotNil(banned)
+	suite.True(banned.VACBanned)
+	suite.Equal(2, banned.VACBans)
+	suite.Equal(models.EconomyBanProbation, banned.EconomyBan)
+	suite.False(results["76561197960287931"].Bans.VACBanned)
+	suite.NotEmpty(results["76561198099999999"].Error)
+
+	// The single lookup used by /summary?include=bans is served from cache
+	bans, err := suite.service.GetPlayerBans(suite.testContext, "76561197960287930")
+	suite.Require().NoError(err)
+	suite.Equal(30, bans.DaysSinceLastBan)
+	suite.Equal(1, suite.steamClient.Calls("GetPlayerBans"))
+}
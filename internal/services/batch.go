@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+)
+
+const (
+	// MaxBatchSteamIDs caps how many ids one batch request may ask for.
+	MaxBatchSteamIDs = 1000
+	// noPlayerFound is the per-id error of ids Steam doesn't know.
+	noPlayerFound = "no player found"
+)
+
+// batchLookup describes a Steam method that answers many steamIDs per call
+// and whose answers are cached one id at a time.
+type batchLookup[T any] struct {
+	// perCall is the most steamids Steam accepts in one call.
+	perCall int
+	// cached returns the value cached for id and whether it is still fresh,
+	// or nil on a miss.
+	cached func(ctx context.Context, id string) (*T, bool)
+	// fetch asks Steam about ids and caches every answer under its own key.
+	fetch func(ctx context.Context, ids []string) (map[string]*T, error)
+}
+
+// batchResult is the answer for one id: the value, or why there is none.
+type batchResult[T any] struct {
+	Value *T
+	Error string
+}
+
+// serveBatch does the work shared by the batch endpoints: it validates and
+// normalizes steamIDs, looks them up, logs the request and converts every
+// answer with result. Per-id failures keep the response a 200, but when not
+// a single id could be answered the upstream error is returned instead.
+func serveBatch[T, R any](ctx context.Context, s *SteamService, endpoint string, steamIDs []string, lookup batchLookup[T], result func(batchResult[T]) R) (map[string]R, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	params := map[string]interface{}{"steam_ids": steamIDs}
+
+	ids, err := normalizeSteamIDs(steamIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, apperrors.NewAPIError(400, "at least one steamID is required")
+	}
+	if len(ids) > MaxBatchSteamIDs {
+		return nil, apperrors.NewAPIError(400, fmt.Sprintf("at most %d steamIDs can be requested at once", MaxBatchSteamIDs))
+	}
+
+	answers, err := lookupBatch(ctx, lookup, ids)
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil && !anyAnswered(answers) {
+		return nil, err
+	}
+
+	results := make(map[string]R, len(answers))
+	for id, answer := range answers {
+		results[id] = result(answer)
+	}
+	return results, nil
+}
+
+// lookupBatch answers every one of the already de-duplicated ids: fresh
+// cache entries directly, the rest from Steam in chunks of lookup.perCall,
+// falling back to stale entries while Steam is unavailable. The returned
+// error is the last chunk failure, if any; its ids carry the message.
+func lookupBatch[T any](ctx context.Context, lookup batchLookup[T], ids []string) (map[string]batchResult[T], error) {
+	results := make(map[string]batchResult[T], len(ids))
+	stale := make(map[string]*T)
+	var misses []string
+	for _, id := range ids {
+		if value, fresh := lookup.cached(ctx, id); value != nil {
+			if fresh {
+				results[id] = batchResult[T]{Value: value}
+				continue
+			}
+			stale[id] = value
+		}
+		misses = append(misses, id)
+	}
+
+	var fetchErr error
+	for chunk := range slices.Chunk(misses, lookup.perCall) {
+		found, err := lookup.fetch(ctx, chunk)
+		for _, id := range chunk {
+			switch {
+			case found[id] != nil:
+				results[id] = batchResult[T]{Value: found[id]}
+			case err != nil && stale[id] != nil && isUpstreamFailure(err):
+				results[id] = batchResult[T]{Value: stale[id]}
+			case err != nil:
+				results[id] = batchResult[T]{Error: apperrors.AsAPIError(err).Message}
+				fetchErr = err
+			default:
+				results[id] = batchResult[T]{Error: noPlayerFound}
+			}
+		}
+	}
+
+	return results, fetchErr
+}
+
+// anyAnswered reports whether any id got a value or a definitive "not found",
+// rather than an upstream failure.
+func anyAnswered[T any](results map[string]batchResult[T]) bool {
+	for _, result := range results {
+		if result.Value != nil || result.Error == noPlayerFound {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package services_test
+
+import (
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestBatchFailsOnlyWhenNothingAnswered() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.Players = map[string]models.PlayerSummary{"76561197960287930": {SteamID: "76561197960287930"}}
+	_, err := suite.service.GetPlayerSummaries(suite.testContext, "76561197960287930")
+	suite.Require().NoError(err)
+	suite.steamClient.SummariesErr = apperrors.NewAPIError(502, "Bad Gateway")
+
+	// A cached player makes it a partial answer, still a 200
+	results, err := suite.service.GetPlayerSummariesBatch(suite.testContext, []string{"76561197960287930", "76561197960287931"})
+	suite.Require().NoError(err)
+	suite.NotNil(results["76561197960287930"].Player)
+	suite.Equal("Bad Gateway", results["76561197960287931"].Error)
+
+	// With nothing answered the upstream error is the response
+	_, err = suite.service.GetPlayerSummariesBatch(suite.testContext, []string{"76561197960287931", "76561197960287932"})
+	var apiErr *apperrors.APIError
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(502, apiErr.StatusCode)
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/cache"
+)
+
+// Cache resources. Each one has its own cache.Policy and is also the prefix
+// of the cache keys it owns.
+const (
+	resourceVanity                       = "vanity"
+	resourceOwnedGames                   = "owned_games"
+	resourceRecentGames                  = "recent_games"
+	resourceSummary                      = "summary"
+	resourceBans                         = "bans"
+	resourceFriends                      = "friends"
+	resourceLevel                        = "level"
+	resourceBadges                       = "badges"
+	resourcePlayerAchievements           = "player_achievements"
+	resourceFetchedPlayerAchievements    = "fetched_player_achievements"
+	resourceAchievementSummary           = "achievement_summary"
+	resourcePartialAchievementSummary    = "partial_achievement_summary"
+	resourceUserStats                    = "user_stats"
+	resourceCurrentPlayers               = "current_players"
+	resourceNews                         = "news"
+	resourceAppDetails                   = "app_details"
+	resourceGameSchema                   = "game_schema"
+	resourceGlobalAchievementPercentages = "global_achievement_percentages"
+)
+
+// defaultPolicy applies to resources missing from Options.CachePolicies.
+var defaultPolicy = cache.Policy{Fresh: 5 * time.Minute}
+
+// cacheEntry is what getOrFetch stores: the value plus when it was fetched,
+// so freshness can be judged independently of the backend TTL.
+type cacheEntry[T any] struct {
+	Value    *T        `json:"value"`
+	StoredAt time.Time `json:"storedAt"`
+}
+
+func (e *cacheEntry[T]) fresh(policy cache.Policy) bool {
+	return time.Since(e.StoredAt) < policy.Fresh
+}
+
+// partial is implemented by results that may be assembled from incomplete
+// upstream data. Partial results are returned to the caller but not cached.
+type partial interface {
+	Partial() bool
+}
+
+func (s *SteamService) policy(resource string) cache.Policy {
+	if policy, ok := s.options.CachePolicies[resource]; ok {
+		return policy
+	}
+	return defaultPolicy
+}
+
+// getOrFetch is a read-through helper: it returns the value cached under key,
+// or calls fetch and caches a successful result according to the resource's
+// policy. Cache failures are logged and never fail the request.
+//
+// Stale entries are served immediately while a background refresh runs, or,
+// when the policy disables refresh, kept as a fallback for when Steam fails
+// with a 5xx, times out, or the call is rate limited. Other fetch errors are returned unchanged.
+func getOrFetch[T any](ctx context.Context, s *SteamService, resource, key string, fetch func(ctx context.Context) (*T, error)) (*T, error) {
+	policy := s.policy(resource)
+
+	entry := readCached[T](ctx, s, key)
+	if entry != nil {
+		if entry.fresh(policy) {
+			return entry.Value, nil
+		}
+		if policy.Refresh {
+			fetchShared(ctx, s, resource, key, fetch, true)
+			return entry.Value, nil
+		}
+	}
+
+	value, err := awaitShared[T](ctx, s, fetchShared(ctx, s, resource, key, fetch, false))
+	if err == nil {
+		return value, nil
+	}
+
+	if entry != nil && isUpstreamFailure(err) {
+		log.Printf("serving stale %s after upstream failure: %v", key, err)
+		return entry.Value, nil
+	}
+	return nil, err
+}
+
+// sharedFetch is an upstream fetch shared by every caller asking for the
+// same key while it runs.
+type sharedFetch struct {
+	key    string
+	cancel context.CancelFunc
+	done   chan struct{}
+	value  any // set before done is closed
+	err    error
+
+	// Guarded by SteamService.fetchMu
+	waiters    int
+	background bool
+}
+
+// fetchShared starts fetch and stores its result, or joins the fetch already
+// running for key, so only one call reaches Steam and every waiter shares its
+// result or error. The fetch is detached from the caller's cancellation, so
+// a waiter giving up does not abort it for the others; see awaitShared for
+// when it is cancelled. Background fetches, which nobody waits for, always
+// run to completion.
+func fetchShared[T any](ctx context.Context, s *SteamService, resource, key string, fetch func(ctx context.Context) (*T, error), background bool) *sharedFetch {
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+	if f, ok := s.fetches[key]; ok {
+		f.background = f.background || background
+		return f
+	}
+	if s.fetches == nil {
+		s.fetches = make(map[string]*sharedFetch)
+	}
+
+	fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	f := &sharedFetch{key: key, cancel: cancel, done: make(chan struct{}), background: background}
+	s.fetches[key] = f
+	go func() {
+		defer cancel()
+		value, err := fetch(fetchCtx)
+		if err == nil {
+			if p, ok := any(value).(partial); !ok || !p.Partial() {
+				writeCached(fetchCtx, s, resource, key, value)
+			}
+		}
+
+		s.fetchMu.Lock()
+		if s.fetches[key] == f {
+			delete(s.fetches, key)
+		}
+		s.fetchMu.Unlock()
+		f.value, f.err = value, err
+		close(f.done)
+	}()
+	return f
+}
+
+// awaitShared waits for f's result. A waiter that is cancelled first,
+// because its request went away or a sibling lookup failed, cancels the
+// fetch when nobody else is waiting for it, so no quota is spent on a result
+// nobody reads. Waiters that time out leave it running instead: its result
+// still reaches the cache, where the next request picks it up.
+func awaitShared[T any](ctx context.Context, s *SteamService, f *sharedFetch) (*T, error) {
+	s.fetchMu.Lock()
+	f.waiters++
+	s.fetchMu.Unlock()
+
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return nil, f.err
+		}
+		return f.value.(*T), nil
+	case <-ctx.Done():
+	}
+
+	s.fetchMu.Lock()
+	f.waiters--
+	abandoned := f.waiters == 0 && !f.background && errors.Is(ctx.Err(), context.Canceled)
+	if abandoned && s.fetches[f.key] == f {
+		// Later callers start a fresh fetch rather than join a cancelled one
+		delete(s.fetches, f.key)
+	}
+	s.fetchMu.Unlock()
+	if abandoned {
+		f.cancel()
+	}
+	return nil, ctx.Err()
+}
+
+// readCached returns the entry stored under key, or nil on a miss. Read
+// failures are logged and treated as misses.
+func readCached[T any](ctx context.Context, s *SteamService, key string) *cacheEntry[T] {
+	entry, err := cache.GetJSON[cacheEntry[T]](ctx, s.Cache, key)
+	if err != nil {
+		if !errors.Is(err, cache.ErrMiss) {
+			log.Printf("failed to read %s from cache: %v", key, err)
+		}
+		return nil
+	}
+	if entry.Value == nil || entry.StoredAt.IsZero() {
+		// Written before entries carried a timestamp
+		return nil
+	}
+	return entry
+}
+
+// writeCached stores value under key for as long as the resource's policy
+// keeps it fresh or stale. Write failures are logged.
+func writeCached[T any](ctx context.Context, s *SteamService, resource, key string, value *T) {
+	entry := cacheEntry[T]{Value: value, StoredAt: time.Now()}
+	if err := cache.SetJSON(ctx, s.Cache, key, entry, s.policy(resource).TTL()); err != nil {
+		log.Printf("failed to cache %s: %v", key, err)
+	}
+}
+
+// isUpstreamFailure reports whether err means Steam is currently unavailable
+// to us (5xx, timeout, or rate limited), as opposed to a definitive answer
+// such as "not found".
+func isUpstreamFailure(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var apiErr *apperrors.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests
+}
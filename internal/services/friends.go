@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+)
+
+// GetFriendList returns steamID's friends with the time each friendship
+// started. With expand set, every friend also carries their decoded player
+// summary, batch-fetched through the summary:<id> cache.
+func (s *SteamService) GetFriendList(ctx context.Context, steamID string, expand bool) (*models.FriendList, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/friends:GetFriendList"
+	params := map[string]interface{}{"steam_id": steamID, "expand": expand}
+
+	cacheKey := fmt.Sprintf("friends:%s", steamID)
+	response, err := getOrFetch(ctx, s, resourceFriends, cacheKey, func(ctx context.Context) (*models.FriendListResponse, error) {
+		response, err := s.steamClient.GetFriendList(ctx, steamID)
+		var apiErr *apperrors.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 401 {
+			// Steam answers 401 when the friend list is not public
+			return nil, apperrors.WrapAPIError(409, err, "GetFriendList, private profile or friend list")
+		}
+		return response, err
+	})
+	if err != nil {
+		s.logResult(ctx, endpoint, params, start, err)
+		return nil, err
+	}
+
+	result := &models.FriendList{
+		SteamID: steamID,
+		Count:   len(response.FriendsList.Friends),
+		Friends: make([]models.Friend, 0, len(response.FriendsList.Friends)),
+	}
+	ids := make([]string, 0, len(response.FriendsList.Friends))
+	for _, f := range response.FriendsList.Friends {
+		result.Friends = append(result.Friends, models.Friend{
+			SteamID:      f.SteamID,
+			Relationship: f.Relationship,
+			FriendSince:  time.Unix(f.FriendSince, 0).UTC(),
+		})
+		ids = append(ids, f.SteamID)
+	}
+
+	if expand && len(ids) > 0 {
+		// A friend whose summary can't be fetched is still listed, just
+		// without a player block
+		summaries, err := lookupBatch(ctx, s.summaryLookup(), uniqueNonEmpty(ids))
+		for i, friend := range result.Friends {
+			if summary := summaries[friend.SteamID]; summary.Value != nil {
+				player := summary.Value.Player()
+				result.Friends[i].Player = &player
+			}
+		}
+		s.logResult(ctx, endpoint, params, start, err)
+		return result, nil
+	}
+
+	s.logResult(ctx, endpoint, params, start, nil)
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package services_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestFriendListExpandsSummaries() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.FriendList = &models.FriendListResponse{}
+	suite.Require().NoError(json.Unmarshal([]byte(`{"friendslist": {"friends": [
+		{"steamid": "76561197960287930", "relationship": "friend", "friend_since": 1600000000},
+		{"steamid": "76561197960287931", "relationship": "friend", "friend_since": 1650000000}
+	]}}`), suite.steamClient.FriendList))
+	suite.steamClient.Players = map[string]models.PlayerSummary{
+		"76561197960287930": {SteamID: "76561197960287930", PersonaName: "Rabscuttle", PersonaState: 1},
+	}
+
+	plain, err := suite.service.GetFriendList(suite.testContext, "76561197960434622", false)
+	suite.Require().NoError(err)
+	suite.Equal(2, plain.Count)
+	suite.Equal(time.Unix(1600000000, 0).UTC(), plain.Friends[0].FriendSince)
+	suite.Nil(plain.Friends[0].Player)
+	suite.Zero(suite.steamClient.Calls("GetPlayerSummaries"))
+
+	expanded, err := suite.service.GetFriendList(suite.testContext, "76561197960434622", true)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(expanded.Friends[0].Player)
+	suite.Equal("Rabscuttle", expanded.Friends[0].Player.PersonaName)
+	suite.Equal(models.PersonaOnline, expanded.Friends[0].Player.PersonaState)
+	suite.Nil(expanded.Friends[1].Player)
+
+	// Both summaries went out in one batched call and the list itself was cached
+	suite.Equal(1, suite.steamClient.Calls("GetPlayerSummaries"))
+	suite.Equal(1, suite.steamClient.Calls("GetFriendList"))
+}
+
+func (suite *SteamServiceTestSuite) TestPrivateFriendListIsConflict() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, false, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := suite.service.GetFriendList(suite.testContext, "76561197960434622", false)
+
+	var apiErr *apperrors.APIError
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(409, apiErr.StatusCode)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"golang.org/x/sync/errgroup"
+)
+
+// Orders accepted by GetGlobalAchievements.
+const (
+	AchievementOrderSchema = ""       // the order the game declares them in
+	AchievementOrderRarest = "rarest" // lowest unlock percentage first
+	AchievementOrderCommon = "common" // highest unlock percentage first
+)
+
+// GetGlobalAchievements returns every achievement of appID, with schema
+// details and the share of players who unlocked it, in the given order.
+func (s *SteamService) GetGlobalAchievements(ctx context.Context, appID, order string) (*models.GameAchievements, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/apps/achievements/global:GetGlobalAchievementPercentages"
+	params := map[string]interface{}{"appID": appID, "order": order}
+
+	var (
+		gameSchema        *models.GameSchemaResponse
+		globalPercentages *models.GlobalAchievementPercentagesResponse
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var apiError *apperrors.APIError
+		if gameSchema, apiError = s.fetchGameSchema(gctx, appID); apiError != nil {
+			return apiError
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var apiError *apperrors.APIError
+		if globalPercentages, apiError = s.fetchGlobalAchievementPercentages(gctx, appID); apiError != nil {
+			return apiError
+		}
+		return nil
+	})
+	err := g.Wait()
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	percentages := achievementPercentages(globalPercentages)
+	result := &models.GameAchievements{
+		AppID:        appID,
+		GameName:     gameSchema.Game.GameName,
+		Achievements: make([]models.GlobalAchievement, 0, len(gameSchema.Game.AvailableGameStats.Achievements)),
+	}
+	for _, ach := range gameSchema.Game.AvailableGameStats.Achievements {
+		result.Achievements = append(result.Achievements, models.GlobalAchievement{
+			Name:        ach.Name,
+			DisplayName: ach.DisplayName,
+			Description: ach.Description,
+			Icon:        ach.Icon,
+			IconGray:    ach.IconGray,
+			Hidden:      ach.Hidden == 1,
+			Percent:     percentages[ach.Name],
+		})
+	}
+
+	switch order {
+	case AchievementOrderRarest:
+		slices.SortStableFunc(result.Achievements, func(a, b models.GlobalAchievement) int {
+			return cmp.Compare(a.Percent, b.Percent)
+		})
+	case AchievementOrderCommon:
+		slices.SortStableFunc(result.Achievements, func(a, b models.GlobalAchievement) int {
+			return cmp.Compare(b.Percent, a.Percent)
+		})
+	}
+	return result, nil
+}
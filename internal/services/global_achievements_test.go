@@ -0,0 +1,46 @@
+package services_test
+
+import (
+	"encoding/json"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/services"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestGlobalAchievementsSortByRarity() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, true, "", mock.Anything, "").Return(nil)
+	suite.Require().NoError(json.Unmarshal([]byte(`{"game": {"gameName": "Test Game", "availableGameStats": {"achievements": [
+		{"name": "ACH_WIN", "displayName": "Winner"},
+		{"name": "ACH_SECRET", "displayName": "Secret", "hidden": 1},
+		{"name": "ACH_LOSE", "displayName": "Loser"}
+	]}}}`), suite.steamClient.GameSchema))
+	suite.Require().NoError(json.Unmarshal([]byte(`{"achievementpercentages": {"achievements": [
+		{"name": "ACH_WIN", "percent": "25.5"},
+		{"name": "ACH_SECRET", "percent": "0.3"},
+		{"name": "ACH_LOSE", "percent": "75.0"}
+	]}}`), suite.steamClient.GlobalPercentages))
+
+	names := func(order string) []string {
+		result, err := suite.service.GetGlobalAchievements(suite.testContext, "123", order)
+		suite.Require().NoError(err)
+		var names []string
+		for _, ach := range result.Achievements {
+			names = append(names, ach.Name)
+		}
+		return names
+	}
+
+	suite.Equal([]string{"ACH_WIN", "ACH_SECRET", "ACH_LOSE"}, names(services.AchievementOrderSchema))
+	suite.Equal([]string{"ACH_SECRET", "ACH_WIN", "ACH_LOSE"}, names(services.AchievementOrderRarest))
+	suite.Equal([]string{"ACH_LOSE", "ACH_WIN", "ACH_SECRET"}, names(services.AchievementOrderCommon))
+
+	result, err := suite.service.GetGlobalAchievements(suite.testContext, "123", services.AchievementOrderSchema)
+	suite.Require().NoError(err)
+	suite.Equal("Test Game", result.GameName)
+	suite.True(result.Achievements[1].Hidden)
+	suite.Equal(0.3, result.Achievements[1].Percent)
+
+	// No steamID involved, and schema and percentages came from cache after the first call
+	suite.Equal(1, suite.steamClient.Calls("GetSchemaForGame"))
+	suite.Zero(suite.steamClient.Calls("GetPlayerAchievements"))
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/Uranury/RBK_fetchAPI/internal/steamid"
+)
+
+// ResolveSteamID turns any identifier a user may paste (SteamID64, SteamID2,
+// SteamID3, profile URL or vanity name) into a 64-bit SteamID. Everything but
+// vanity names is converted offline; malformed input fails with a 400
+// without reaching Steam.
+func (s *SteamService) ResolveSteamID(ctx context.Context, input string) (string, error) {
+	identifier, err := steamid.ParseIdentifier(input)
+	if err != nil {
+		return "", invalidSteamIDError(input, err)
+	}
+	if identifier.Vanity != "" {
+		return s.ResolveVanityURL(ctx, identifier.Vanity)
+	}
+	if !identifier.ID.IsIndividual() {
+		return "", apperrors.NewAPIError(400, fmt.Sprintf("%q is not a user account", input))
+	}
+	return identifier.ID.String(), nil
+}
+
+// ConvertSteamID returns every representation of the account input names.
+// Unlike ResolveSteamID it accepts any valid account, not just users, so
+// groups and game servers can be converted too.
+func (s *SteamService) ConvertSteamID(ctx context.Context, input string) (*models.SteamIDConversion, error) {
+	identifier, err := steamid.ParseIdentifier(input)
+	if err != nil {
+		return nil, invalidSteamIDError(input, err)
+	}
+
+	id := identifier.ID
+	if identifier.Vanity != "" {
+		resolved, err := s.ResolveVanityURL(ctx, identifier.Vanity)
+		if err != nil {
+			return nil, err
+		}
+		if id, err = steamid.Parse(resolved); err != nil {
+			return nil, apperrors.WrapAPIError(502, err, "Steam returned an invalid SteamID")
+		}
+	}
+
+	conversion := &models.SteamIDConversion{
+		SteamID64:   id.String(),
+		SteamID2:    id.Steam2(),
+		SteamID3:    id.Steam3(),
+		AccountID:   id.AccountID(),
+		Universe:    id.Universe().String(),
+		AccountType: id.AccountType().String(),
+		Instance:    id.Instance(),
+	}
+	if id.IsIndividual() {
+		conversion.ProfileURL = fmt.Sprintf("https://steamcommunity.com/profiles/%s", id)
+	}
+	return conversion, nil
+}
+
+// normalizeSteamIDs converts SteamIDs and profile URLs to SteamID64 offline
+// and drops blanks and duplicates. Batch endpoints use it instead of
+// ResolveSteamID so a single request can't fan out into hundreds of vanity
+// lookups; vanity names and /id/ URLs are rejected.
+func normalizeSteamIDs(values []string) ([]string, error) {
+	ids := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		identifier, err := steamid.ParseIdentifier(value)
+		if err != nil {
+			return nil, invalidSteamIDError(value, err)
+		}
+		if identifier.Vanity != "" {
+			return nil, apperrors.NewAPIError(400, fmt.Sprintf("%q is a vanity name, which batch lookups don't resolve", value))
+		}
+		if !identifier.ID.IsIndividual() {
+			return nil, apperrors.NewAPIError(400, fmt.Sprintf("%q is not a user account", value))
+		}
+		ids = append(ids, identifier.ID.String())
+	}
+	return uniqueNonEmpty(ids), nil
+}
+
+func invalidSteamIDError(input string, err error) error {
+	if errors.Is(err, steamid.ErrInvalid) {
+		return apperrors.NewAPIError(400, fmt.Sprintf("%q is not a valid Steam ID, profile URL or vanity name", input))
+	}
+	return err
+}
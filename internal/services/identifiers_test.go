@@ -0,0 +1,94 @@
+package services_test
+
+import (
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestResolveSteamIDConvertsOffline() {
+	for _, input := range []string{
+		"76561197960287930",
+		"STEAM_0:0:11101",
+		"[U:1:22202]",
+		"https://steamcommunity.com/profiles/76561197960287930/",
+	} {
+		steamID, err := suite.service.ResolveSteamID(suite.testContext, input)
+		suite.Require().NoError(err, input)
+		suite.Equal("76561197960287930", steamID, input)
+	}
+	suite.Equal(0, suite.steamClient.Calls("ResolveVanityURL"))
+}
+
+func (suite *SteamServiceTestSuite) TestResolveSteamIDResolvesVanityNames() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.Vanities = map[string]string{"gabelogannewell": "76561197960287930"}
+
+	for _, input := range []string{"gabelogannewell", "https://steamcommunity.com/id/gabelogannewell/"} {
+		steamID, err := suite.service.ResolveSteamID(suite.testContext, input)
+		suite.Require().NoError(err, input)
+		suite.Equal("76561197960287930", steamID, input)
+	}
+	// The second lookup is served from the vanity cache
+	suite.Equal(1, suite.steamClient.Calls("ResolveVanityURL"))
+
+	_, err := suite.service.ResolveSteamID(suite.testContext, "nobody_here")
+	suite.Equal(404, apperrors.AsAPIError(err).StatusCode)
+}
+
+func (suite *SteamServiceTestSuite) TestResolveSteamIDRejectsMalformedInput() {
+	for _, input := range []string{"x", "not a name", "[g:1:4]", "https://steamcommunity.com/profiles/garbage"} {
+		_, err := suite.service.ResolveSteamID(suite.testContext, input)
+		suite.Require().Error(err, input)
+		suite.Equal(400, apperrors.AsAPIError(err).StatusCode, input)
+	}
+	suite.Equal(0, suite.steamClient.Calls("ResolveVanityURL"))
+}
+
+func (suite *SteamServiceTestSuite) TestBatchLookupsNormalizeSteamIDs() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	results, err := suite.service.GetPlayerBansBatch(suite.testContext, []string{"STEAM_0:0:11101", "[U:1:22202]", "76561197960287930", "https://steamcommunity.com/profiles/76561197960287930/"})
+	suite.Require().NoError(err)
+	suite.Len(results, 1)
+	suite.Contains(results, "76561197960287930")
+	suite.Equal([][]string{{"76561197960287930"}}, suite.steamClient.Batches)
+
+	// Vanity names would need one Steam call each
+	for _, vanity := range []string{"gabelogannewell", "https://steamcommunity.com/id/gabelogannewell"} {
+		_, err = suite.service.GetPlayerSummariesBatch(suite.testContext, []string{"76561197960287930", vanity})
+		suite.Equal(400, apperrors.AsAPIError(err).StatusCode, vanity)
+	}
+	suite.Equal(0, suite.steamClient.Calls("ResolveVanityURL"))
+}
+
+func (suite *SteamServiceTestSuite) TestConvertSteamID() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.Vanities = map[string]string{"gabelogannewell": "76561197960287930"}
+
+	for _, input := range []string{"STEAM_0:0:11101", "gabelogannewell"} {
+		conversion, err := suite.service.ConvertSteamID(suite.testContext, input)
+		suite.Require().NoError(err, input)
+		suite.Equal(&models.SteamIDConversion{
+			SteamID64:   "76561197960287930",
+			SteamID2:    "STEAM_0:0:11101",
+			SteamID3:    "[U:1:22202]",
+			AccountID:   22202,
+			Universe:    "Public",
+			AccountType: "Individual",
+			Instance:    1,
+			ProfileURL:  "https://steamcommunity.com/profiles/76561197960287930",
+		}, conversion, input)
+	}
+
+	// Groups have no SteamID2 or profile URL but still convert
+	group, err := suite.service.ConvertSteamID(suite.testContext, "[g:1:4]")
+	suite.Require().NoError(err)
+	suite.Equal("103582791429521412", group.SteamID64)
+	suite.Equal("Clan", group.AccountType)
+	suite.Empty(group.SteamID2)
+	suite.Empty(group.ProfileURL)
+
+	_, err = suite.service.ConvertSteamID(suite.testContext, "[U:7:1]")
+	suite.Equal(400, apperrors.AsAPIError(err).StatusCode)
+}
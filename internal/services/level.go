@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"golang.org/x/sync/errgroup"
+)
+
+// privateProfileError reports that Steam answered with an empty payload
+// because the profile is not public.
+func privateProfileError(operation string) error {
+	return apperrors.NewAPIError(409, fmt.Sprintf("%s, private profile", operation))
+}
+
+// GetSteamLevel returns steamID's level from GetSteamLevel together with the
+// XP progress, which Steam only reports through GetBadges. Both lookups run
+// in parallel and share their caches with GetBadges.
+func (s *SteamService) GetSteamLevel(ctx context.Context, steamID string) (*models.PlayerLevel, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/level:GetSteamLevel"
+	params := map[string]interface{}{"steam_id": steamID}
+
+	var (
+		level  *models.SteamLevelResponse
+		badges *models.BadgesResponse
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		level, err = getOrFetch(gctx, s, resourceLevel, fmt.Sprintf("level:%s", steamID), func(ctx context.Context) (*models.SteamLevelResponse, error) {
+			response, err := s.steamClient.GetSteamLevel(ctx, steamID)
+			if err == nil && response.Response.PlayerLevel == nil {
+				return nil, privateProfileError("GetSteamLevel")
+			}
+			return response, err
+		})
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		badges, err = s.fetchBadges(gctx, steamID)
+		return err
+	})
+	err := g.Wait()
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	result := playerLevel(steamID, badges)
+	result.Level = *level.Response.PlayerLevel
+	return &result, nil
+}
+
+// GetBadges returns steamID's badges with their completion times, along with
+// the player's level and XP.
+func (s *SteamService) GetBadges(ctx context.Context, steamID string) (*models.PlayerBadges, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/badges:GetBadges"
+	params := map[string]interface{}{"steam_id": steamID}
+
+	response, err := s.fetchBadges(ctx, steamID)
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.PlayerBadges{
+		PlayerLevel: playerLevel(steamID, response),
+		Count:       len(response.Response.Badges),
+		Badges:      make([]models.Badge, 0, len(response.Response.Badges)),
+	}
+	for _, b := range response.Response.Badges {
+		result.Badges = append(result.Badges, models.Badge{
+			BadgeID:     b.BadgeID,
+			AppID:       b.AppID,
+			Level:       b.Level,
+			XP:          b.XP,
+			Scarcity:    b.Scarcity,
+			Foil:        b.BorderColor == 1,
+			CompletedAt: time.Unix(b.CompletionTime, 0).UTC(),
+		})
+	}
+	return result, nil
+}
+
+func (s *SteamService) fetchBadges(ctx context.Context, steamID string) (*models.BadgesResponse, error) {
+	cacheKey := fmt.Sprintf("badges:%s", steamID)
+	return getOrFetch(ctx, s, resourceBadges, cacheKey, func(ctx context.Context) (*models.BadgesResponse, error) {
+		response, err := s.steamClient.GetBadges(ctx, steamID)
+		if err == nil && response.Response.PlayerXP == nil {
+			return nil, privateProfileError("GetBadges")
+		}
+		return response, err
+	})
+}
+
+func playerLevel(steamID string, badges *models.BadgesResponse) models.PlayerLevel {
+	return models.PlayerLevel{
+		SteamID:        steamID,
+		Level:          badges.Response.PlayerLevel,
+		XP:             *badges.Response.PlayerXP,
+		XPToNextLevel:  badges.Response.PlayerXPNeededToLevelUp,
+		XPCurrentLevel: badges.Response.PlayerXPNeededCurrentLevel,
+	}
+}
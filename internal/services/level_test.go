@@ -0,0 +1,48 @@
+package services_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestLevelAndBadgesShareBadgesCache() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.Level = &models.SteamLevelResponse{}
+	suite.Require().NoError(json.Unmarshal([]byte(`{"response": {"player_level": 42}}`), suite.steamClient.Level))
+	suite.steamClient.Badges = &models.BadgesResponse{}
+	suite.Require().NoError(json.Unmarshal([]byte(`{"response": {
+		"badges": [
+			{"badgeid": 1, "level": 10, "completion_time": 1600000000, "xp": 500, "scarcity": 1000},
+			{"badgeid": 1, "appid": 440, "level": 1, "completion_time": 1650000000, "xp": 100, "scarcity": 50, "border_color": 1}
+		],
+		"player_xp": 9350, "player_level": 42, "player_xp_needed_to_level_up": 150, "player_xp_needed_current_level": 9300
+	}}`), suite.steamClient.Badges))
+
+	level, err := suite.service.GetSteamLevel(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.Equal(models.PlayerLevel{SteamID: "76561197960434622", Level: 42, XP: 9350, XPToNextLevel: 150, XPCurrentLevel: 9300}, *level)
+
+	badges, err := suite.service.GetBadges(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.Equal(2, badges.Count)
+	suite.Equal(9350, badges.XP)
+	suite.Equal(time.Unix(1600000000, 0).UTC(), badges.Badges[0].CompletedAt)
+	suite.True(badges.Badges[1].Foil)
+	suite.Equal(440, badges.Badges[1].AppID)
+
+	suite.Equal(1, suite.steamClient.Calls("GetBadges"))
+}
+
+func (suite *SteamServiceTestSuite) TestPrivateLevelIsConflict() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, false, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := suite.service.GetSteamLevel(suite.testContext, "76561197960434622")
+
+	var apiErr *apperrors.APIError
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(409, apiErr.StatusCode)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+)
+
+// DefaultTopGames is how many games GetLibraryStats ranks when the caller
+// doesn't say.
+const DefaultTopGames = 10
+
+type playtimeBucket struct {
+	label string
+	max   int
+}
+
+// playtimeBuckets are the upper bounds, in minutes, of the playtime
+// distribution; games above the last one fall into an open-ended bucket.
+var playtimeBuckets = []playtimeBucket{
+	{label: "never played", max: 0},
+	{label: "under 1h", max: 59},
+	{label: "1-10h", max: 10*60 - 1},
+	{label: "10-50h", max: 50*60 - 1},
+	{label: "50-100h", max: 100*60 - 1},
+}
+
+// GetLibraryStats computes analytics over the user's owned games. It is
+// derived from GetOwnedGames on every call, so it is exactly as fresh as
+// that cache entry.
+func (s *SteamService) GetLibraryStats(ctx context.Context, steamID string, top int) (*models.LibraryStats, error) {
+	owned, err := s.GetOwnedGames(ctx, steamID)
+	if err != nil {
+		return nil, err
+	}
+	if top <= 0 {
+		top = DefaultTopGames
+	}
+	return libraryStats(steamID, owned.Response.Games, top), nil
+}
+
+func libraryStats(steamID string, games []models.OwnedGame, top int) *models.LibraryStats {
+	stats := &models.LibraryStats{
+		SteamID:      steamID,
+		TotalGames:   len(games),
+		TopGames:     []models.OwnedGame{},
+		Distribution: make([]models.PlaytimeBucket, 0, len(playtimeBuckets)+1),
+	}
+
+	minPlaytime := 0
+	for _, bucket := range playtimeBuckets {
+		stats.Distribution = append(stats.Distribution, models.PlaytimeBucket{
+			Label:       bucket.label,
+			MinPlaytime: minPlaytime,
+			MaxPlaytime: &bucket.max,
+		})
+		minPlaytime = bucket.max + 1
+	}
+	stats.Distribution = append(stats.Distribution, models.PlaytimeBucket{
+		Label:       "100h+",
+		MinPlaytime: minPlaytime,
+	})
+
+	if len(games) == 0 {
+		return stats
+	}
+
+	// games may be shared with the cache, so rank a copy
+	ranked := slices.Clone(games)
+	slices.SortStableFunc(ranked, func(a, b models.OwnedGame) int {
+		return cmp.Compare(b.PlaytimeForever, a.PlaytimeForever)
+	})
+
+	for _, game := range ranked {
+		stats.TotalPlaytime += game.PlaytimeForever
+		if game.PlaytimeForever == 0 {
+			stats.NeverPlayed++
+		}
+		if game.HasCommunityVisibleStats {
+			stats.GamesWithCommunityStats++
+		}
+		i, _ := slices.BinarySearchFunc(playtimeBuckets, game.PlaytimeForever, func(bucket playtimeBucket, playtime int) int {
+			return cmp.Compare(bucket.max, playtime)
+		})
+		stats.Distribution[i].Games++
+	}
+
+	n := len(ranked)
+	if n%2 == 1 {
+		stats.MedianPlaytime = float64(ranked[n/2].PlaytimeForever)
+	} else {
+		stats.MedianPlaytime = float64(ranked[n/2-1].PlaytimeForever+ranked[n/2].PlaytimeForever) / 2
+	}
+	stats.NeverPlayedShare = float64(stats.NeverPlayed) / float64(n)
+	stats.CommunityStatsShare = float64(stats.GamesWithCommunityStats) / float64(n)
+
+	// Unplayed games would only pad the ranking
+	played := n - stats.NeverPlayed
+	stats.TopGames = ranked[:min(top, played)]
+	return stats
+}
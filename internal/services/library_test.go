@@ -0,0 +1,73 @@
+package services_test
+
+import (
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestLibraryStats() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.OwnedGames = &models.OwnedGamesResponse{}
+	suite.steamClient.OwnedGames.Response.GameCount = 6
+	suite.steamClient.OwnedGames.Response.Games = []models.OwnedGame{
+		{AppID: 10, Name: "Counter-Strike", PlaytimeForever: 12000, HasCommunityVisibleStats: true},
+		{AppID: 20, Name: "Team Fortress Classic", PlaytimeForever: 0},
+		{AppID: 30, Name: "Day of Defeat", PlaytimeForever: 59},
+		{AppID: 40, Name: "Deathmatch Classic", PlaytimeForever: 0},
+		{AppID: 50, Name: "Half-Life: Opposing Force", PlaytimeForever: 600, HasCommunityVisibleStats: true},
+		{AppID: 60, Name: "Ricochet", PlaytimeForever: 60},
+	}
+
+	stats, err := suite.service.GetLibraryStats(suite.testContext, "76561197960434622", 3)
+	suite.Require().NoError(err)
+
+	suite.Equal(6, stats.TotalGames)
+	suite.Equal(12719, stats.TotalPlaytime)
+	suite.Equal(59.5, stats.MedianPlaytime)
+	suite.Equal(2, stats.NeverPlayed)
+	suite.InDelta(1.0/3, stats.NeverPlayedShare, 1e-9)
+	suite.Equal(2, stats.GamesWithCommunityStats)
+	suite.InDelta(1.0/3, stats.CommunityStatsShare, 1e-9)
+
+	suite.Require().Len(stats.TopGames, 3)
+	suite.Equal([]int{10, 50, 60}, []int{stats.TopGames[0].AppID, stats.TopGames[1].AppID, stats.TopGames[2].AppID})
+
+	counts := make(map[string]int)
+	for _, bucket := range stats.Distribution {
+		counts[bucket.Label] = bucket.Games
+	}
+	suite.Equal(map[string]int{"never played": 2, "under 1h": 1, "1-10h": 1, "10-50h": 1, "50-100h": 0, "100h+": 1}, counts)
+	suite.Nil(stats.Distribution[len(stats.Distribution)-1].MaxPlaytime)
+
+	// The cached owned games list keeps Steam's order
+	owned, err := suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.Equal(10, owned.Response.Games[0].AppID)
+	suite.Equal(20, owned.Response.Games[1].AppID)
+}
+
+func (suite *SteamServiceTestSuite) TestLibraryStatsTopSkipsUnplayedGames() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.OwnedGames = &models.OwnedGamesResponse{}
+	suite.steamClient.OwnedGames.Response.Games = []models.OwnedGame{
+		{AppID: 10, PlaytimeForever: 5},
+		{AppID: 20},
+	}
+
+	stats, err := suite.service.GetLibraryStats(suite.testContext, "76561197960434622", 0)
+	suite.Require().NoError(err)
+	suite.Len(stats.TopGames, 1)
+	suite.Equal(5.0/2, stats.MedianPlaytime)
+}
+
+func (suite *SteamServiceTestSuite) TestLibraryStatsEmptyLibrary() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.OwnedGames = &models.OwnedGamesResponse{}
+
+	stats, err := suite.service.GetLibraryStats(suite.testContext, "76561197960434622", 0)
+	suite.Require().NoError(err)
+	suite.Zero(stats.TotalGames)
+	suite.Zero(stats.NeverPlayedShare)
+	suite.NotNil(stats.TopGames)
+	suite.Len(stats.Distribution, 6)
+}
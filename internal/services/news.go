@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+)
+
+// GetAppNews returns appID's latest news items, newest first. Every distinct
+// filter is cached separately.
+func (s *SteamService) GetAppNews(ctx context.Context, appID string, filter models.NewsFilter) (*models.AppNews, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/apps/news:GetNewsForApp"
+	params := map[string]interface{}{"appID": appID, "count": filter.Count, "maxlength": filter.MaxLength, "feeds": filter.Feeds}
+
+	filter.Feeds = slices.Sorted(slices.Values(uniqueNonEmpty(filter.Feeds)))
+	cacheKey := fmt.Sprintf("news:%s:%d:%d:%s", appID, filter.Count, filter.MaxLength, strings.Join(filter.Feeds, ","))
+	response, err := getOrFetch(ctx, s, resourceNews, cacheKey, func(ctx context.Context) (*models.NewsForAppResponse, error) {
+		return s.steamClient.GetNewsForApp(ctx, appID, filter)
+	})
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Steam leaves appid out when there is no news at all
+	id, _ := strconv.Atoi(appID)
+	news := &models.AppNews{
+		AppID: id,
+		Total: response.AppNews.Count,
+		Items: make([]models.NewsItem, 0, len(response.AppNews.NewsItems)),
+	}
+	for _, item := range response.AppNews.NewsItems {
+		news.Items = append(news.Items, models.NewsItem{
+			ID:        item.GID,
+			Title:     item.Title,
+			URL:       item.URL,
+			External:  item.IsExternalURL,
+			Author:    item.Author,
+			Contents:  item.Contents,
+			FeedName:  item.FeedName,
+			FeedLabel: item.FeedLabel,
+			Date:      time.Unix(item.Date, 0).UTC(),
+		})
+	}
+	return news, nil
+}
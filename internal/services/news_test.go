@@ -0,0 +1,33 @@
+package services_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestAppNewsIsDecodedAndCachedPerFilter() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.News = &models.NewsForAppResponse{}
+	suite.Require().NoError(json.Unmarshal([]byte(`{"appnews": {"appid": 440, "count": 3012, "newsitems": [
+		{"gid": "5123", "title": "Update", "url": "https://example.com/5123", "is_external_url": true,
+		 "author": "Valve", "contents": "Fixed a crash", "feedlabel": "TF2 Blog", "date": 1710439200, "feedname": "tf2_blog"}
+	]}}`), suite.steamClient.News))
+
+	news, err := suite.service.GetAppNews(suite.testContext, "440", models.NewsFilter{Count: 5, Feeds: []string{"tf2_blog", "steam_community_announcements"}})
+	suite.Require().NoError(err)
+	suite.Equal(440, news.AppID)
+	suite.Equal(3012, news.Total)
+	suite.Require().Len(news.Items, 1)
+	suite.Equal(time.Unix(1710439200, 0).UTC(), news.Items[0].Date)
+	suite.True(news.Items[0].External)
+
+	// The same feeds in another order hit the cache; another count doesn't
+	_, err = suite.service.GetAppNews(suite.testContext, "440", models.NewsFilter{Count: 5, Feeds: []string{"steam_community_announcements", "tf2_blog"}})
+	suite.Require().NoError(err)
+	_, err = suite.service.GetAppNews(suite.testContext, "440", models.NewsFilter{Count: 10})
+	suite.Require().NoError(err)
+	suite.Equal(2, suite.steamClient.Calls("GetNewsForApp"))
+}
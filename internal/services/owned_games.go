@@ -0,0 +1,75 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+)
+
+// QueryOwnedGames returns the page of the user's owned games that query
+// selects. Filtering runs over the cached owned games list, so paging
+// through a library costs a single Steam call.
+func (s *SteamService) QueryOwnedGames(ctx context.Context, steamID string, query models.OwnedGamesQuery) (*models.OwnedGamesPage, error) {
+	owned, err := s.GetOwnedGames(ctx, steamID)
+	if err != nil {
+		return nil, err
+	}
+	return queryOwnedGames(owned, query), nil
+}
+
+func queryOwnedGames(owned *models.OwnedGamesResponse, query models.OwnedGamesQuery) *models.OwnedGamesPage {
+	search := strings.ToLower(query.Search)
+	// Filtering into a new slice also keeps the cached list's order intact
+	games := make([]models.OwnedGame, 0, len(owned.Response.Games))
+	for _, game := range owned.Response.Games {
+		switch {
+		case query.MinPlaytime != nil && game.PlaytimeForever < *query.MinPlaytime,
+			query.MaxPlaytime != nil && game.PlaytimeForever > *query.MaxPlaytime,
+			query.Played != nil && (game.PlaytimeForever > 0) != *query.Played,
+			search != "" && !strings.Contains(strings.ToLower(game.Name), search):
+			continue
+		}
+		games = append(games, game)
+	}
+
+	if compare := gameComparator(query.Sort); compare != nil {
+		slices.SortStableFunc(games, func(a, b models.OwnedGame) int {
+			if query.Descending {
+				a, b = b, a
+			}
+			// Ties fall back to the app ID so pages are stable
+			return cmp.Or(compare(a, b), cmp.Compare(a.AppID, b.AppID))
+		})
+	}
+
+	page := &models.OwnedGamesPage{}
+	page.Response.GameCount = owned.Response.GameCount
+	page.Response.Total = len(games)
+	page.Response.Offset = query.Offset
+
+	end := len(games)
+	if query.Limit > 0 {
+		end = min(end, query.Offset+query.Limit)
+	}
+	page.Response.Games = games[min(query.Offset, end):end]
+	return page
+}
+
+func gameComparator(sort string) func(a, b models.OwnedGame) int {
+	switch sort {
+	case models.GameSortAppID:
+		return func(a, b models.OwnedGame) int { return cmp.Compare(a.AppID, b.AppID) }
+	case models.GameSortName:
+		return func(a, b models.OwnedGame) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		}
+	case models.GameSortPlaytime:
+		return func(a, b models.OwnedGame) int { return cmp.Compare(a.PlaytimeForever, b.PlaytimeForever) }
+	case models.GameSortLastPlayed:
+		return func(a, b models.OwnedGame) int { return cmp.Compare(a.RTimeLastPlayed, b.RTimeLastPlayed) }
+	}
+	return nil
+}
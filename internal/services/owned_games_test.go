@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) useLibrary() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.OwnedGames = &models.OwnedGamesResponse{}
+	suite.steamClient.OwnedGames.Response.GameCount = 5
+	suite.steamClient.OwnedGames.Response.Games = []models.OwnedGame{
+		{AppID: 400, Name: "Portal", PlaytimeForever: 300, RTimeLastPlayed: 1600000000},
+		{AppID: 620, Name: "Portal 2", PlaytimeForever: 900, RTimeLastPlayed: 1700000000},
+		{AppID: 70, Name: "Half-Life", PlaytimeForever: 0},
+		{AppID: 220, Name: "Half-Life 2", PlaytimeForever: 900, RTimeLastPlayed: 1500000000},
+		{AppID: 10, Name: "counter-strike", PlaytimeForever: 20},
+	}
+}
+
+func appIDs(page *models.OwnedGamesPage) []int {
+	ids := make([]int, 0, len(page.Response.Games))
+	for _, game := range page.Response.Games {
+		ids = append(ids, game.AppID)
+	}
+	return ids
+}
+
+func (suite *SteamServiceTestSuite) TestQueryOwnedGamesWithoutOptionsReturnsLibrary() {
+	suite.useLibrary()
+
+	page, err := suite.service.QueryOwnedGames(suite.testContext, "76561197960434622", models.OwnedGamesQuery{})
+	suite.Require().NoError(err)
+	suite.Equal(5, page.Response.GameCount)
+	suite.Equal(5, page.Response.Total)
+	suite.Equal([]int{400, 620, 70, 220, 10}, appIDs(page))
+}
+
+func (suite *SteamServiceTestSuite) TestQueryOwnedGamesSorts() {
+	suite.useLibrary()
+
+	tests := []struct {
+		query models.OwnedGamesQuery
+		want  []int
+	}{
+		// Equal playtimes fall back to the app ID
+		{query: models.OwnedGamesQuery{Sort: models.GameSortPlaytime, Descending: true}, want: []int{620, 220, 400, 10, 70}},
+		{query: models.OwnedGamesQuery{Sort: models.GameSortPlaytime}, want: []int{70, 10, 400, 220, 620}},
+		{query: models.OwnedGamesQuery{Sort: models.GameSortName}, want: []int{10, 70, 220, 400, 620}},
+		{query: models.OwnedGamesQuery{Sort: models.GameSortAppID}, want: []int{10, 70, 220, 400, 620}},
+		{query: models.OwnedGamesQuery{Sort: models.GameSortLastPlayed, Descending: true}, want: []int{620, 400, 220, 70, 10}},
+	}
+	for _, tt := range tests {
+		page, err := suite.service.QueryOwnedGames(suite.testContext, "76561197960434622", tt.query)
+		suite.Require().NoError(err)
+		suite.Equal(tt.want, appIDs(page), tt.query.Sort)
+	}
+
+	// Sorting works on a copy; the cached library keeps Steam's order
+	owned, err := suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.Equal(400, owned.Response.Games[0].AppID)
+	suite.Equal(1, suite.steamClient.Calls("GetOwnedGames"))
+}
+
+func (suite *SteamServiceTestSuite) TestQueryOwnedGamesFiltersAndPages() {
+	suite.useLibrary()
+	played, minPlaytime, maxPlaytime := true, 20, 900
+
+	page, err := suite.service.QueryOwnedGames(suite.testContext, "76561197960434622", models.OwnedGamesQuery{
+		Sort:        models.GameSortAppID,
+		MinPlaytime: &minPlaytime,
+		MaxPlaytime: &maxPlaytime,
+		Played:      &played,
+		Offset:      1,
+		Limit:       2,
+	})
+	suite.Require().NoError(err)
+	suite.Equal(5, page.Response.GameCount)
+	suite.Equal(4, page.Response.Total)
+	suite.Equal(1, page.Response.Offset)
+	suite.Equal([]int{220, 400}, appIDs(page))
+
+	page, err = suite.service.QueryOwnedGames(suite.testContext, "76561197960434622", models.OwnedGamesQuery{Search: "PORTAL"})
+	suite.Require().NoError(err)
+	suite.Equal([]int{400, 620}, appIDs(page))
+
+	notPlayed := false
+	page, err = suite.service.QueryOwnedGames(suite.testContext, "76561197960434622", models.OwnedGamesQuery{Played: &notPlayed})
+	suite.Require().NoError(err)
+	suite.Equal([]int{70}, appIDs(page))
+
+	// Past the end is an empty page, not an error
+	page, err = suite.service.QueryOwnedGames(suite.testContext, "76561197960434622", models.OwnedGamesQuery{Offset: 10, Limit: 2})
+	suite.Require().NoError(err)
+	suite.Equal(5, page.Response.Total)
+	suite.NotNil(page.Response.Games)
+	suite.Empty(page.Response.Games)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/Uranury/RBK_fetchAPI/internal/repositories"
+)
+
+// MaxPlayerHistoryBuckets caps how many buckets one history query may span.
+const MaxPlayerHistoryBuckets = 5000
+
+// GetCurrentPlayers returns how many players are in appID right now.
+func (s *SteamService) GetCurrentPlayers(ctx context.Context, appID string) (*models.CurrentPlayers, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/apps/players:GetNumberOfCurrentPlayers"
+	params := map[string]interface{}{"appID": appID}
+
+	cacheKey := fmt.Sprintf("current_players:%s", appID)
+	response, err := getOrFetch(ctx, s, resourceCurrentPlayers, cacheKey, s.fetchCurrentPlayers(appID))
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return currentPlayers(appID, response), nil
+}
+
+// RefreshCurrentPlayers is GetCurrentPlayers without reading the cache: it
+// always asks Steam and caches the answer, so samples are never stale.
+func (s *SteamService) RefreshCurrentPlayers(ctx context.Context, appID string) (*models.CurrentPlayers, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/apps/players:GetNumberOfCurrentPlayers"
+	params := map[string]interface{}{"appID": appID}
+
+	cacheKey := fmt.Sprintf("current_players:%s", appID)
+	fetch := fetchShared(ctx, s, resourceCurrentPlayers, cacheKey, s.fetchCurrentPlayers(appID), false)
+	response, err := awaitShared[models.NumberOfCurrentPlayersResponse](ctx, s, fetch)
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return currentPlayers(appID, response), nil
+}
+
+func (s *SteamService) fetchCurrentPlayers(appID string) func(ctx context.Context) (*models.NumberOfCurrentPlayersResponse, error) {
+	return func(ctx context.Context) (*models.NumberOfCurrentPlayersResponse, error) {
+		response, err := s.steamClient.GetNumberOfCurrentPlayers(ctx, appID)
+		if err == nil && response.Response.Result != 1 {
+			return nil, apperrors.NewAPIError(404, fmt.Sprintf("no player count for appID %s", appID))
+		}
+		return response, err
+	}
+}
+
+func currentPlayers(appID string, response *models.NumberOfCurrentPlayersResponse) *models.CurrentPlayers {
+	id, _ := strconv.Atoi(appID)
+	return &models.CurrentPlayers{AppID: id, Players: response.Response.PlayerCount}
+}
+
+// PlayerCountService records current player counts over time and serves
+// them back as aggregated time series.
+type PlayerCountService struct {
+	steamService    *SteamService
+	playerCountRepo repositories.PlayerCountRepository
+}
+
+func NewPlayerCountService(steamService *SteamService, playerCountRepo repositories.PlayerCountRepository) *PlayerCountService {
+	return &PlayerCountService{steamService: steamService, playerCountRepo: playerCountRepo}
+}
+
+// RunSampler records the player count of every app in appIDs once per
+// interval until ctx is cancelled. The first round runs immediately.
+func (p *PlayerCountService) RunSampler(ctx context.Context, appIDs []int, interval time.Duration) {
+	log.Printf("sampling player counts of %d apps every %s", len(appIDs), interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.Sample(ctx, appIDs)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sample records the current player count of every app in appIDs, fetched
+// from Steam rather than the cache. Apps that fail are logged and skipped
+// until the next round.
+func (p *PlayerCountService) Sample(ctx context.Context, appIDs []int) {
+	sampledAt := time.Now().UTC().Truncate(time.Second)
+	for _, appID := range appIDs {
+		current, err := p.steamService.RefreshCurrentPlayers(ctx, strconv.Itoa(appID))
+		if err != nil {
+			log.Printf("failed to sample player count of app %d: %v", appID, err)
+			continue
+		}
+		if err := p.playerCountRepo.SaveSample(appID, sampledAt, current.Players); err != nil {
+			log.Printf("failed to save player count of app %d: %v", appID, err)
+		}
+	}
+}
+
+// GetHistory returns min, max and average player counts of appID per bucket
+// between from and to. Only sampled apps have history.
+func (p *PlayerCountService) GetHistory(ctx context.Context, appID int, from, to time.Time, bucket time.Duration) (*models.PlayerCountHistory, error) {
+	if !from.Before(to) {
+		return nil, apperrors.NewAPIError(400, "from must be before to")
+	}
+	if bucket < time.Minute || bucket%time.Second != 0 {
+		return nil, apperrors.NewAPIError(400, "bucket must be a whole number of seconds, at least 1m")
+	}
+	if to.Sub(from)/bucket > MaxPlayerHistoryBuckets {
+		return nil, apperrors.NewAPIError(400, fmt.Sprintf("at most %d buckets can be requested at once", MaxPlayerHistoryBuckets))
+	}
+
+	buckets, err := p.playerCountRepo.GetHistory(appID, from, to, bucket)
+	if err != nil {
+		return nil, apperrors.WrapAPIError(500, err, "failed to read player count history")
+	}
+
+	return &models.PlayerCountHistory{
+		AppID:   appID,
+		From:    from.UTC(),
+		To:      to.UTC(),
+		Bucket:  bucket.String(),
+		Buckets: buckets,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package services_test
+
+import (
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/cache"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/Uranury/RBK_fetchAPI/internal/services"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPlayerCountRepository is a mock implementation of PlayerCountRepository
+type MockPlayerCountRepository struct {
+	mock.Mock
+}
+
+func (m *MockPlayerCountRepository) SaveSample(appID int, sampledAt time.Time, players int) error {
+	args := m.Called(appID, sampledAt, players)
+	return args.Error(0)
+}
+
+func (m *MockPlayerCountRepository) GetHistory(appID int, from, to time.Time, bucket time.Duration) ([]models.PlayerCountBucket, error) {
+	args := m.Called(appID, from, to, bucket)
+	return args.Get(0).([]models.PlayerCountBucket), args.Error(1)
+}
+
+func (suite *SteamServiceTestSuite) TestSamplerRecordsKnownApps() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.CurrentPlayers = map[string]int{"570": 812345, "730": 1203456}
+	countRepo := new(MockPlayerCountRepository)
+	countRepo.On("SaveSample", 570, mock.AnythingOfType("time.Time"), 812345).Return(nil).Once()
+	countRepo.On("SaveSample", 730, mock.AnythingOfType("time.Time"), 1203456).Return(nil).Once()
+
+	// The unknown app is skipped without stopping the round
+	services.NewPlayerCountService(suite.service, countRepo).Sample(suite.testContext, []int{570, 1, 730})
+
+	countRepo.AssertExpectations(suite.T())
+}
+
+func (suite *SteamServiceTestSuite) TestSamplerRecordsFreshCounts() {
+	suite.service = services.NewSteamService(suite.steamClient, suite.steamClient, suite.cache, suite.repoMock, services.Options{
+		CachePolicies: map[string]cache.Policy{"current_players": {Fresh: time.Millisecond, Stale: time.Hour, Refresh: true}},
+	})
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.steamClient.CurrentPlayers = map[string]int{"570": 812345}
+	_, err := suite.service.GetCurrentPlayers(suite.testContext, "570")
+	suite.Require().NoError(err)
+
+	// Past the fresh window a cached read would still serve the old count
+	time.Sleep(5 * time.Millisecond)
+	suite.steamClient.CurrentPlayers = map[string]int{"570": 900000}
+	countRepo := new(MockPlayerCountRepository)
+	countRepo.On("SaveSample", 570, mock.AnythingOfType("time.Time"), 900000).Return(nil).Once()
+
+	services.NewPlayerCountService(suite.service, countRepo).Sample(suite.testContext, []int{570})
+
+	countRepo.AssertExpectations(suite.T())
+	current, err := suite.service.GetCurrentPlayers(suite.testContext, "570")
+	suite.Require().NoError(err)
+	suite.Equal(900000, current.Players)
+}
+
+func (suite *SteamServiceTestSuite) TestPlayerHistoryValidatesRange() {
+	countRepo := new(MockPlayerCountRepository)
+	playerCounts := services.NewPlayerCountService(suite.service, countRepo)
+	to := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		from   time.Time
+		bucket time.Duration
+	}{
+		{name: "from after to", from: to.Add(time.Hour), bucket: time.Hour},
+		{name: "bucket too small", from: to.Add(-time.Hour), bucket: time.Second},
+		{name: "too many buckets", from: to.AddDate(-1, 0, 0), bucket: time.Minute},
+	}
+	for _, tt := range tests {
+		_, err := playerCounts.GetHistory(suite.testContext, 570, tt.from, to, tt.bucket)
+
+		var apiErr *apperrors.APIError
+		suite.Require().ErrorAs(err, &apiErr, tt.name)
+		suite.Equal(400, apiErr.StatusCode, tt.name)
+	}
+
+	buckets := []models.PlayerCountBucket{{Start: to.Add(-time.Hour), Min: 10, Max: 20, Avg: 15, Samples: 6}}
+	countRepo.On("GetHistory", 570, to.Add(-24*time.Hour), to, time.Hour).Return(buckets, nil)
+
+	history, err := playerCounts.GetHistory(suite.testContext, 570, to.Add(-24*time.Hour), to, time.Hour)
+	suite.Require().NoError(err)
+	suite.Equal("1h0m0s", history.Bucket)
+	suite.Equal(buckets, history.Buckets)
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/Uranury/RBK_fetchAPI/internal/repositories"
+)
+
+// QuotaService tracks outbound Steam calls against each API key's daily
+// budget, since Steam enforces its limit per key. Keys are identified by
+// clients.KeyID. Days are counted in UTC.
+type QuotaService struct {
+	quotaRepo repositories.QuotaRepository
+	budget    int
+	keys      map[string]string
+}
+
+// quotaTimeout bounds each counter update, so a slow database delays Steam
+// calls by at most this much.
+const quotaTimeout = 500 * time.Millisecond
+
+// NewQuotaService returns a QuotaService allowing budget calls per key and
+// day. keys maps the id of every configured key to its masked form, so usage
+// reports include keys that haven't been used yet today and show each one
+// the way people recognise it.
+func NewQuotaService(quotaRepo repositories.QuotaRepository, budget int, keys map[string]string) *QuotaService {
+	return &QuotaService{quotaRepo: quotaRepo, budget: budget, keys: keys}
+}
+
+// Reserve records one call to endpoint with the key keyID, or returns a 429
+// APIError once that key's budget for today is spent. A budget of 0
+// disables the limit. The check and the increment are a single atomic
+// database statement. Failures to update the counters are logged and never
+// block the call.
+func (q *QuotaService) Reserve(ctx context.Context, keyID, endpoint string) error {
+	ctx, cancel := context.WithTimeout(ctx, quotaTimeout)
+	defer cancel()
+
+	reserved, err := q.quotaRepo.Reserve(ctx, today(), keyID, endpoint, q.budget)
+	if err != nil {
+		log.Printf("failed to record quota usage for %s with key %s: %v", endpoint, keyID, err)
+		return nil
+	}
+	if !reserved {
+		err := fmt.Errorf("all %d calls of key %s used today", q.budget, keyID)
+		return apperrors.WrapAPIError(429, err, "Steam API daily budget exhausted")
+	}
+	return nil
+}
+
+func (q *QuotaService) GetUsage(ctx context.Context) (*models.QuotaUsage, error) {
+	day := today()
+	byKey, err := q.quotaRepo.GetDailyUsage(ctx, day)
+	if err != nil {
+		return nil, apperrors.WrapAPIError(500, err, "failed to read quota usage")
+	}
+
+	usage := &models.QuotaUsage{
+		Date:      day.Format(time.DateOnly),
+		Budget:    q.budget,
+		Endpoints: make(map[string]int),
+		Keys:      make(map[string]models.KeyQuotaUsage),
+	}
+	for keyID := range q.keys {
+		if _, ok := byKey[keyID]; !ok {
+			byKey[keyID] = map[string]int{}
+		}
+	}
+	for keyID, endpoints := range byKey {
+		keyUsage := models.KeyQuotaUsage{Key: q.keys[keyID], Endpoints: endpoints}
+		for endpoint, calls := range endpoints {
+			keyUsage.Used += calls
+			usage.Endpoints[endpoint] += calls
+		}
+		if q.budget > 0 {
+			keyUsage.Remaining = max(q.budget-keyUsage.Used, 0)
+		}
+		usage.Used += keyUsage.Used
+		usage.Remaining += keyUsage.Remaining
+		usage.Keys[keyID] = keyUsage
+	}
+	return usage, nil
+}
+
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
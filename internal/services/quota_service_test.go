@@ -0,0 +1,92 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/Uranury/RBK_fetchAPI/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeQuotaRepository keeps quota counters in memory
+type FakeQuotaRepository struct {
+	mu    sync.Mutex
+	usage map[string]map[string]int // key -> endpoint -> calls, for a single day
+	Err   error
+}
+
+func (r *FakeQuotaRepository) Reserve(ctx context.Context, day time.Time, apiKey, endpoint string, budget int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Err != nil {
+		return false, r.Err
+	}
+	total := 0
+	for _, calls := range r.usage[apiKey] {
+		total += calls
+	}
+	if budget > 0 && total >= budget {
+		return false, nil
+	}
+	if r.usage[apiKey] == nil {
+		r.usage[apiKey] = make(map[string]int)
+	}
+	r.usage[apiKey][endpoint]++
+	return true, nil
+}
+
+func (r *FakeQuotaRepository) GetDailyUsage(ctx context.Context, day time.Time) (map[string]map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	usage := make(map[string]map[string]int, len(r.usage))
+	for key, endpoints := range r.usage {
+		usage[key] = make(map[string]int, len(endpoints))
+		for endpoint, calls := range endpoints {
+			usage[key][endpoint] = calls
+		}
+	}
+	return usage, nil
+}
+
+func newFakeQuotaRepository() *FakeQuotaRepository {
+	return &FakeQuotaRepository{usage: make(map[string]map[string]int)}
+}
+
+func TestQuotaReserveStopsAtBudgetPerKey(t *testing.T) {
+	quota := services.NewQuotaService(newFakeQuotaRepository(), 2, map[string]string{"key-1": "****0001", "key-2": "****0002", "key-3": "****0003"})
+	ctx := context.Background()
+
+	require.NoError(t, quota.Reserve(ctx, "key-1", "GetOwnedGames"))
+	require.NoError(t, quota.Reserve(ctx, "key-1", "GetPlayerSummaries"))
+	err := quota.Reserve(ctx, "key-1", "GetOwnedGames")
+	require.Error(t, err)
+	assert.Equal(t, 429, apperrors.AsAPIError(err).StatusCode)
+
+	// Another key has its own budget
+	require.NoError(t, quota.Reserve(ctx, "key-2", "GetOwnedGames"))
+
+	usage, err := quota.GetUsage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, usage.Budget)
+	assert.Equal(t, 3, usage.Used)
+	assert.Equal(t, 3, usage.Remaining)
+	assert.Equal(t, map[string]int{"GetOwnedGames": 2, "GetPlayerSummaries": 1}, usage.Endpoints)
+	assert.Equal(t, models.KeyQuotaUsage{Key: "****0001", Used: 2, Remaining: 0, Endpoints: map[string]int{"GetOwnedGames": 1, "GetPlayerSummaries": 1}}, usage.Keys["key-1"])
+	assert.Equal(t, 1, usage.Keys["key-2"].Remaining)
+	// Keys not used yet today are listed too
+	assert.Equal(t, models.KeyQuotaUsage{Key: "****0003", Remaining: 2, Endpoints: map[string]int{}}, usage.Keys["key-3"])
+}
+
+func TestQuotaReserveFailsOpen(t *testing.T) {
+	repo := newFakeQuotaRepository()
+	repo.Err = errors.New("database unavailable")
+	quota := services.NewQuotaService(repo, 1, map[string]string{"key-1": "****0001"})
+
+	assert.NoError(t, quota.Reserve(context.Background(), "key-1", "GetOwnedGames"))
+}
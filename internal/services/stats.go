@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"golang.org/x/sync/errgroup"
+)
+
+// GetPlayerStats returns steamID's numeric stats in appID, merged with the
+// game schema: every stat the schema declares is listed in schema order with
+// its display name, using the default value for stats Steam left out.
+func (s *SteamService) GetPlayerStats(ctx context.Context, steamID, appID string) (*models.PlayerStats, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/stats:GetUserStatsForGame"
+	params := map[string]interface{}{"steamID": steamID, "appID": appID}
+
+	var (
+		userStats  *models.UserStatsForGameResponse
+		gameSchema *models.GameSchemaResponse
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		userStats, err = s.fetchUserStats(gctx, steamID, appID)
+		return err
+	})
+	g.Go(func() error {
+		var apiError *apperrors.APIError
+		if gameSchema, apiError = s.fetchGameSchema(gctx, appID); apiError != nil {
+			return apiError
+		}
+		return nil
+	})
+	err := g.Wait()
+	s.logResult(ctx, endpoint, params, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]float64, len(userStats.PlayerStats.Stats))
+	for _, stat := range userStats.PlayerStats.Stats {
+		values[stat.Name] = stat.Value
+	}
+
+	result := &models.PlayerStats{
+		SteamID:  steamID,
+		AppID:    appID,
+		GameName: userStats.PlayerStats.GameName,
+		Stats:    make([]models.PlayerStat, 0, len(gameSchema.Game.AvailableGameStats.Stats)),
+	}
+	declared := make(map[string]struct{}, len(gameSchema.Game.AvailableGameStats.Stats))
+	for _, schemaStat := range gameSchema.Game.AvailableGameStats.Stats {
+		declared[schemaStat.Name] = struct{}{}
+		stat := models.PlayerStat{
+			Name:         schemaStat.Name,
+			DisplayName:  schemaStat.DisplayName,
+			Value:        schemaStat.DefaultValue,
+			DefaultValue: schemaStat.DefaultValue,
+		}
+		if value, ok := values[schemaStat.Name]; ok {
+			stat.Value = value
+		}
+		result.Stats = append(result.Stats, stat)
+	}
+	// Stats the schema doesn't know about are still returned, under their
+	// API name
+	for _, userStat := range userStats.PlayerStats.Stats {
+		if _, ok := declared[userStat.Name]; !ok {
+			result.Stats = append(result.Stats, models.PlayerStat{Name: userStat.Name, DisplayName: userStat.Name, Value: userStat.Value})
+		}
+	}
+
+	return result, nil
+}
+
+func (s *SteamService) fetchUserStats(ctx context.Context, steamID, appID string) (*models.UserStatsForGameResponse, error) {
+	cacheKey := fmt.Sprintf("user_stats:%s:game:%s", steamID, appID)
+
+	return getOrFetch(ctx, s, resourceUserStats, cacheKey, func(ctx context.Context) (*models.UserStatsForGameResponse, error) {
+		result, err := s.steamClient.GetUserStatsForGame(ctx, steamID, appID)
+		if err != nil {
+			// Steam rejects the call outright for private profiles and for
+			// games without stats
+			if status := apperrors.AsAPIError(err).StatusCode; status == 400 || status == 403 {
+				return nil, apperrors.WrapAPIError(409, err, "fetchUserStats, invalid appID or private profile")
+			}
+			return nil, err
+		}
+		return result, nil
+	})
+}
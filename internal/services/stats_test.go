@@ -0,0 +1,91 @@
+package services_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/Uranury/RBK_fetchAPI/internal/services"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestStatsMergeWithSchema() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, true, "", mock.Anything, mock.Anything).Return(nil)
+	suite.Require().NoError(json.Unmarshal([]byte(`{"game": {"gameName": "Test Game", "availableGameStats": {"stats": [
+		{"name": "total_kills", "defaultvalue": 0, "displayName": "Total Kills"},
+		{"name": "distance", "defaultvalue": 1.5, "displayName": "Distance Walked"}
+	]}}}`), suite.steamClient.GameSchema))
+	suite.steamClient.UserStats = &models.UserStatsForGameResponse{}
+	suite.Require().NoError(json.Unmarshal([]byte(`{"playerstats": {"steamID": "76561197960434622", "gameName": "Test Game", "stats": [
+		{"name": "total_kills", "value": 1337},
+		{"name": "legacy_stat", "value": 3}
+	]}}`), suite.steamClient.UserStats))
+
+	result, err := suite.service.GetPlayerStats(suite.testContext, "76561197960434622", "123")
+	suite.Require().NoError(err)
+
+	suite.Equal([]models.PlayerStat{
+		{Name: "total_kills", DisplayName: "Total Kills", Value: 1337},
+		{Name: "distance", DisplayName: "Distance Walked", Value: 1.5, DefaultValue: 1.5},
+		{Name: "legacy_stat", DisplayName: "legacy_stat", Value: 3},
+	}, result.Stats)
+}
+
+func (suite *SteamServiceTestSuite) TestPrivateStatsAreConflict() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, false, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := suite.service.GetPlayerStats(suite.testContext, "76561197960434622", "123")
+
+	var apiErr *apperrors.APIError
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(409, apiErr.StatusCode)
+}
+
+func (suite *SteamServiceTestSuite) TestPrivateStatsKeepKeysUsable() {
+	var (
+		mu         sync.Mutex
+		seen       []string
+		statsCalls int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, r.URL.Query().Get("key"))
+		if r.URL.Path == "/ISteamUserStats/GetUserStatsForGame/v0002/" {
+			statsCalls++
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"playerstats": {"error": "Profile is not public", "success": false}}`))
+			return
+		}
+		w.Write([]byte(`{"game": {"gameName": "Test Game"}}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewSteamClient([]string{"a", "b", "c"}, server.URL, server.Client(), clients.Options{
+		KeyCooldown: time.Hour,
+	})
+	suite.service = services.NewSteamService(client, suite.steamClient, suite.cache, suite.repoMock, services.Options{})
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, false, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := suite.service.GetPlayerStats(suite.testContext, "76561197960434622", "123")
+
+	var apiErr *apperrors.APIError
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(409, apiErr.StatusCode)
+	suite.Equal(1, statsCalls)
+
+	// No key was sidelined, so the next calls still rotate through all three
+	mu.Lock()
+	seen = nil
+	mu.Unlock()
+	for i := 0; i < 3; i++ {
+		_, err := client.GetSchemaForGame(suite.testContext, "440")
+		suite.Require().NoError(err)
+	}
+	suite.ElementsMatch([]string{"a", "b", "c"}, seen)
+}
@@ -2,216 +2,166 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/cache"
+	"github.com/Uranury/RBK_fetchAPI/internal/clients"
 	"github.com/Uranury/RBK_fetchAPI/internal/models"
 	"github.com/Uranury/RBK_fetchAPI/internal/repositories"
-	"github.com/redis/go-redis/v9"
 )
 
-const (
-	resolveVanityURLTemplate = "http://api.steampowered.com/ISteamUser/ResolveVanityURL/v0001/?key=%s&vanityurl=%s"
-	ownedGamesURLTemplate    = "https://api.steampowered.com/IPlayerService/GetOwnedGames/v1/?key=%s&steamid=%s&include_appinfo=true"
-	playerSummariesTemplate  = "http://api.steampowered.com/ISteamUser/GetPlayerSummaries/v0002/?key=%s&steamids=%s"
-)
+// Options tunes optional SteamService behaviour.
+type Options struct {
+	// DegradeMissingRarity makes GetPlayerAchievements return achievements
+	// with zero rarity, flagged in the response, when global percentages
+	// can't be fetched, instead of failing the whole request.
+	DegradeMissingRarity bool
+
+	// AchievementSummaryConcurrency bounds how many games
+	// GetAchievementSummary fetches at once, and AchievementSummaryBudget how
+	// long it spends fetching before returning a partial summary. Zero
+	// values select the defaults.
+	AchievementSummaryConcurrency int
+	AchievementSummaryBudget      time.Duration
+
+	// CachePolicies maps a cache resource (the cache key prefix, e.g.
+	// "game_schema") to its freshness policy.
+	CachePolicies map[string]cache.Policy
+}
 
 type SteamService struct {
-	APIKey     string
-	Cache      *redis.Client
-	steamRepo  repositories.SteamRepository
-	HTTPClient *http.Client
+	Cache       cache.Cache
+	steamRepo   repositories.SteamRepository
+	steamClient clients.SteamClient
+	storeClient clients.StoreClient
+	options     Options
+
+	// fetches deduplicates concurrent upstream fetches by cache key.
+	fetchMu sync.Mutex
+	fetches map[string]*sharedFetch
 }
 
-func NewSteamService(APIKey string, Cache *redis.Client, steamRepo repositories.SteamRepository, client *http.Client) *SteamService {
+func NewSteamService(steamClient clients.SteamClient, storeClient clients.StoreClient, Cache cache.Cache, steamRepo repositories.SteamRepository, options Options) *SteamService {
 	return &SteamService{
-		APIKey:     APIKey,
-		Cache:      Cache,
-		steamRepo:  steamRepo,
-		HTTPClient: client,
+		Cache:       Cache,
+		steamRepo:   steamRepo,
+		steamClient: steamClient,
+		storeClient: storeClient,
+		options:     options,
 	}
 }
 
-func (s *SteamService) logRequest(endpoint string, params map[string]interface{}, success bool, errorMsg string, duration time.Duration) {
-	if err := s.steamRepo.SaveRequestHistory(endpoint, params, success, errorMsg, duration); err != nil {
+func (s *SteamService) logRequest(ctx context.Context, endpoint string, params map[string]interface{}, success bool, errorMsg string, duration time.Duration) {
+	if err := s.steamRepo.SaveRequestHistory(endpoint, params, success, errorMsg, duration, clients.UsedKeys(ctx)); err != nil {
 		log.Printf("failed to save request history: %v", err)
 	}
 }
 
+// logResult records the outcome of a service call started at start. A lookup
+// that reached Steam but found nothing (404) still counts as a successful
+// request; the not-found message is kept for reference.
+func (s *SteamService) logResult(ctx context.Context, endpoint string, params map[string]interface{}, start time.Time, err error) {
+	switch {
+	case err == nil:
+		s.logRequest(ctx, endpoint, params, true, "", time.Since(start))
+	case apperrors.AsAPIError(err).StatusCode == http.StatusNotFound:
+		s.logRequest(ctx, endpoint, params, true, err.Error(), time.Since(start))
+	default:
+		s.logRequest(ctx, endpoint, params, false, err.Error(), time.Since(start))
+	}
+}
+
 func (s *SteamService) ResolveVanityURL(ctx context.Context, vanityName string) (string, error) {
 	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
 	endpoint := "/steam_id:ResolveVanityURL"
 	params := map[string]interface{}{"vanityName": vanityName}
 
 	cacheKey := fmt.Sprintf("vanity:%s", vanityName)
-	if steamID, err := s.Cache.Get(ctx, cacheKey).Result(); err == nil {
-		s.logRequest(endpoint, params, true, "", time.Since(start))
-		return steamID, nil
-	}
-
-	url := fmt.Sprintf(resolveVanityURLTemplate, s.APIKey, vanityName)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return "", apperrors.WrapAPIError(500, err, "ResolveVanityURL request creation failed")
-	}
-
-	resp, err := s.HTTPClient.Do(req)
+	steamID, err := getOrFetch(ctx, s, resourceVanity, cacheKey, func(ctx context.Context) (*string, error) {
+		result, err := s.steamClient.ResolveVanityURL(ctx, vanityName)
+		if err != nil {
+			return nil, err
+		}
+		if result.Response.Success != 1 {
+			err := fmt.Errorf("could not resolve vanity URL: %s", result.Response.Message)
+			return nil, apperrors.WrapAPIError(404, err, "No match")
+		}
+		return &result.Response.SteamID, nil
+	})
+	s.logResult(ctx, endpoint, params, start, err)
 	if err != nil {
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return "", apperrors.WrapAPIError(500, err, "ResolveVanityURL request failed")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("steam API responded with status: %s", resp.Status)
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return "", apperrors.WrapAPIError(resp.StatusCode, err, "Unexpected error")
+		return "", err
 	}
-
-	var result struct {
-		Response struct {
-			SteamID string `json:"steamid"`
-			Success int    `json:"success"`
-			Message string `json:"message,omitempty"`
-		} `json:"response"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return "", apperrors.WrapAPIError(500, err, "ResolveVanityURL JSON decode failed")
-	}
-
-	if result.Response.Success != 1 {
-		err := fmt.Errorf("could not resolve vanity URL: %s", result.Response.Message)
-		s.logRequest(endpoint, params, true, "", time.Since(start))
-		return "", apperrors.WrapAPIError(404, err, "No match")
-	}
-
-	if err := s.Cache.Set(ctx, cacheKey, result.Response.SteamID, 5*time.Minute).Err(); err != nil {
-		log.Printf("failed to cache vanity: %v", err)
-	}
-
-	s.logRequest(endpoint, params, true, "", time.Since(start))
-	return result.Response.SteamID, nil
+	return *steamID, nil
 }
 
 func (s *SteamService) GetOwnedGames(ctx context.Context, steamID string) (*models.OwnedGamesResponse, error) {
 	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
 	endpoint := "/games:GetOwnedGames"
 	params := map[string]interface{}{"steam_id": steamID}
 
 	cacheKey := fmt.Sprintf("owned_games:%s", steamID)
-	cached, err := s.Cache.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var games models.OwnedGamesResponse
-		if err := json.Unmarshal([]byte(cached), &games); err == nil {
-			s.logRequest(endpoint, params, true, "", time.Since(start))
-			return &games, nil
+	games, err := getOrFetch(ctx, s, resourceOwnedGames, cacheKey, func(ctx context.Context) (*models.OwnedGamesResponse, error) {
+		response, err := s.steamClient.GetOwnedGames(ctx, steamID)
+		if err == nil && response.Response.GameCount == 0 {
+			log.Printf("no games found for steamID: %s", steamID)
 		}
-		log.Printf("failed to unmarshal cached games: %v", err)
-	}
-
-	url := fmt.Sprintf(ownedGamesURLTemplate, s.APIKey, steamID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return nil, apperrors.WrapAPIError(500, err, "GetOwnedGames request creation failed")
-	}
-
-	resp, err := s.HTTPClient.Do(req)
-	if err != nil {
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return nil, apperrors.WrapAPIError(500, err, "GetOwnedGames API call failed")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("steam API responded with status: %s", resp.Status)
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return nil, apperrors.WrapAPIError(resp.StatusCode, err, "Steam GetOwnedGames returned non-200")
-	}
-
-	var response models.OwnedGamesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return nil, apperrors.WrapAPIError(500, err, "GetOwnedGames JSON decode failed")
-	}
-
-	if response.Response.GameCount == 0 {
-		log.Printf("no games found for steamID: %s", steamID)
-	}
+		return response, err
+	})
+	s.logResult(ctx, endpoint, params, start, err)
+	return games, err
+}
 
-	bytes, err := json.Marshal(response)
-	if err == nil {
-		if err := s.Cache.Set(ctx, cacheKey, bytes, 5*time.Minute).Err(); err != nil {
-			log.Printf("failed to cache owned games: %v", err)
-		}
-	}
+func (s *SteamService) GetRecentlyPlayedGames(ctx context.Context, steamID string) (*models.RecentlyPlayedGamesResponse, error) {
+	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
+	endpoint := "/recent:GetRecentlyPlayedGames"
+	params := map[string]interface{}{"steam_id": steamID}
 
-	s.logRequest(endpoint, params, true, "", time.Since(start))
-	return &response, nil
+	cacheKey := fmt.Sprintf("recent_games:%s", steamID)
+	games, err := getOrFetch(ctx, s, resourceRecentGames, cacheKey, func(ctx context.Context) (*models.RecentlyPlayedGamesResponse, error) {
+		return s.steamClient.GetRecentlyPlayedGames(ctx, steamID)
+	})
+	s.logResult(ctx, endpoint, params, start, err)
+	return games, err
 }
 
 func (s *SteamService) GetPlayerSummaries(ctx context.Context, steamID string) (*models.Summary, error) {
 	start := time.Now()
+	ctx = clients.WithKeyTracking(ctx)
 	endpoint := "/summary:GetPlayerSummaries"
 	params := map[string]interface{}{"steam_id": steamID}
 
 	cacheKey := fmt.Sprintf("summary:%s", steamID)
-	cached, err := s.Cache.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var summary models.Summary
-		if err := json.Unmarshal([]byte(cached), &summary); err == nil {
-			s.logRequest(endpoint, params, true, "", time.Since(start))
-			return &summary, nil
+	summary, err := getOrFetch(ctx, s, resourceSummary, cacheKey, func(ctx context.Context) (*models.Summary, error) {
+		result, err := s.steamClient.GetPlayerSummaries(ctx, []string{steamID})
+		if err != nil {
+			return nil, err
 		}
-		log.Printf("failed to unmarshal cached summary: %v", err)
-	}
-
-	url := fmt.Sprintf(playerSummariesTemplate, s.APIKey, steamID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return nil, apperrors.WrapAPIError(500, err, "GetPlayerSummaries request creation failed")
-	}
+		if len(result.Response.Players) == 0 {
+			err := fmt.Errorf("no player found for steamID: %s", steamID)
+			return nil, apperrors.WrapAPIError(404, err, "No player found")
+		}
+		return result, nil
+	})
+	s.logResult(ctx, endpoint, params, start, err)
+	return summary, err
+}
 
-	resp, err := s.HTTPClient.Do(req)
+// GetPlayer returns the decoded profile of a single player.
+func (s *SteamService) GetPlayer(ctx context.Context, steamID string) (*models.Player, error) {
+	summary, err := s.GetPlayerSummaries(ctx, steamID)
 	if err != nil {
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return nil, apperrors.WrapAPIError(500, err, "GetPlayerSummaries API call failed")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("steam API responded with status: %s", resp.Status)
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return nil, apperrors.WrapAPIError(resp.StatusCode, err, "Steam GetPlayerSummaries returned non-200")
-	}
-
-	var result models.Summary
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		s.logRequest(endpoint, params, false, err.Error(), time.Since(start))
-		return nil, apperrors.WrapAPIError(500, err, "GetPlayerSummaries JSON decode failed")
-	}
-
-	if len(result.Response.Players) == 0 {
-		err := fmt.Errorf("no player found for steamID: %s", steamID)
-		s.logRequest(endpoint, params, true, err.Error(), time.Since(start))
-		return nil, apperrors.WrapAPIError(404, err, "No player found")
-	}
-
-	bytes, err := json.Marshal(result)
-	if err == nil {
-		if err := s.Cache.Set(ctx, cacheKey, bytes, 5*time.Minute).Err(); err != nil {
-			log.Printf("failed to cache player summary: %v", err)
-		}
+		return nil, err
 	}
 
-	s.logRequest(endpoint, params, true, "", time.Since(start))
-	return &result, nil
+	player := summary.Response.Players[0].Player()
+	return &player, nil
 }
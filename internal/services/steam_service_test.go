@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/apperrors"
+	"github.com/Uranury/RBK_fetchAPI/internal/cache"
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/Uranury/RBK_fetchAPI/internal/services"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestConcurrentMissesAreCoalesced() {
+	suite.steamClient.OwnedGames = &models.OwnedGamesResponse{}
+	suite.steamClient.OwnedGames.Response.GameCount = 1
+	suite.steamClient.Gate = make(chan struct{})
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	results := make([]*models.OwnedGamesResponse, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+		}(i)
+	}
+
+	// Give every caller time to miss the cache and queue up behind the first fetch
+	time.Sleep(50 * time.Millisecond)
+	close(suite.steamClient.Gate)
+	wg.Wait()
+
+	suite.Equal(1, suite.steamClient.Calls("GetOwnedGames"))
+	for _, result := range results {
+		suite.Require().NotNil(result)
+		suite.Equal(1, result.Response.GameCount)
+	}
+}
+
+func (suite *SteamServiceTestSuite) TestCoalescedCallersShareErrors() {
+	suite.steamClient.Gate = make(chan struct{})
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	const callers = 5
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(suite.steamClient.Gate)
+	wg.Wait()
+
+	suite.Equal(1, suite.steamClient.Calls("GetOwnedGames"))
+	for _, err := range errs {
+		suite.Error(err)
+	}
+}
+
+func (suite *SteamServiceTestSuite) useOwnedGamesPolicy(policy cache.Policy) {
+	suite.service = services.NewSteamService(suite.steamClient, suite.steamClient, suite.cache, suite.repoMock, services.Options{
+		CachePolicies: map[string]cache.Policy{"owned_games": policy},
+	})
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+}
+
+func ownedGames(count int) *models.OwnedGamesResponse {
+	games := &models.OwnedGamesResponse{}
+	games.Response.GameCount = count
+	return games
+}
+
+func (suite *SteamServiceTestSuite) TestStaleEntryIsServedWhileRefreshing() {
+	suite.useOwnedGamesPolicy(cache.Policy{Fresh: time.Millisecond, Stale: time.Hour, Refresh: true})
+	suite.steamClient.OwnedGames = ownedGames(1)
+
+	first, err := suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.Equal(1, first.Response.GameCount)
+
+	time.Sleep(5 * time.Millisecond)
+	suite.steamClient.OwnedGames = ownedGames(2)
+
+	stale, err := suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.Equal(1, stale.Response.GameCount)
+
+	// The background refresh eventually replaces the stale entry
+	suite.Eventually(func() bool {
+		return suite.steamClient.Calls("GetOwnedGames") == 2
+	}, time.Second, time.Millisecond)
+	suite.Eventually(func() bool {
+		games, err := suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+		return err == nil && games.Response.GameCount == 2
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (suite *SteamServiceTestSuite) TestStaleEntryIsFallbackOnUpstreamFailure() {
+	suite.useOwnedGamesPolicy(cache.Policy{Fresh: time.Millisecond, Stale: time.Hour})
+	suite.steamClient.OwnedGames = ownedGames(1)
+
+	_, err := suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	time.Sleep(5 * time.Millisecond)
+
+	suite.steamClient.OwnedGamesErr = apperrors.NewAPIError(502, "bad gateway")
+	games, err := suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+	suite.Require().NoError(err)
+	suite.Equal(1, games.Response.GameCount)
+
+	// A definitive answer from Steam is not papered over
+	suite.steamClient.OwnedGamesErr = apperrors.NewAPIError(403, "forbidden")
+	_, err = suite.service.GetOwnedGames(suite.testContext, "76561197960434622")
+	suite.Error(err)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+)
+
+// summariesPerCall is the most steamids Steam accepts in one
+// GetPlayerSummaries call.
+const summariesPerCall = 100
+
+// GetPlayerSummariesBatch looks up many players at once. Fresh per-player
+// cache entries (the same summary:<id> keys GetPlayerSummaries uses) are
+// served directly; the rest are fetched from Steam in chunks of 100. The
+// result holds one entry per distinct steamID, with an error message for ids
+// Steam doesn't know or that couldn't be fetched. When no id could be
+// answered at all, the upstream error is returned instead.
+func (s *SteamService) GetPlayerSummariesBatch(ctx context.Context, steamIDs []string) (map[string]models.PlayerSummaryResult, error) {
+	return serveBatch(ctx, s, "/summaries:GetPlayerSummaries", steamIDs, s.summaryLookup(), func(r batchResult[models.PlayerSummary]) models.PlayerSummaryResult {
+		return models.PlayerSummaryResult{Player: r.Value, Error: r.Error}
+	})
+}
+
+// summaryLookup batches GetPlayerSummaries over the summary:<id> entries,
+// which hold a one-player response in the shape GetPlayerSummaries caches.
+func (s *SteamService) summaryLookup() batchLookup[models.PlayerSummary] {
+	return batchLookup[models.PlayerSummary]{
+		perCall: summariesPerCall,
+		cached: func(ctx context.Context, id string) (*models.PlayerSummary, bool) {
+			entry := readCached[models.Summary](ctx, s, fmt.Sprintf("summary:%s", id))
+			if entry == nil || len(entry.Value.Response.Players) == 0 {
+				return nil, false
+			}
+			return &entry.Value.Response.Players[0], entry.fresh(s.policy(resourceSummary))
+		},
+		fetch: s.fetchSummaryChunk,
+	}
+}
+
+// fetchSummaryChunk fetches up to 100 players and caches each one under its
+// own summary:<id> key, in the same shape GetPlayerSummaries caches.
+func (s *SteamService) fetchSummaryChunk(ctx context.Context, steamIDs []string) (map[string]*models.PlayerSummary, error) {
+	summary, err := s.steamClient.GetPlayerSummaries(ctx, steamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*models.PlayerSummary, len(summary.Response.Players))
+	for _, player := range summary.Response.Players {
+		found[player.SteamID] = &player
+
+		single := &models.Summary{}
+		single.Response.Players = []models.PlayerSummary{player}
+		writeCached(ctx, s, resourceSummary, fmt.Sprintf("summary:%s", player.SteamID), single)
+	}
+	return found, nil
+}
+
+func uniqueNonEmpty(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	var unique []string
+	for _, v := range values {
+		if _, ok := seen[v]; ok || v == "" {
+			continue
+		}
+		seen[v] = struct{}{}
+		unique = append(unique, v)
+	}
+	return unique
+}
@@ -0,0 +1,46 @@
+package services_test
+
+import (
+	"fmt"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *SteamServiceTestSuite) TestSummariesBatchUsesCacheAndChunks() {
+	suite.repoMock.On("SaveRequestHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// 150 known players plus one Steam doesn't know
+	suite.steamClient.Players = make(map[string]models.PlayerSummary)
+	var ids []string
+	for i := 0; i < 150; i++ {
+		id := fmt.Sprintf("7656119800000%04d", i)
+		suite.steamClient.Players[id] = models.PlayerSummary{SteamID: id, PersonaName: fmt.Sprintf("player %d", i)}
+		ids = append(ids, id)
+	}
+	ids = append(ids, "76561198099999999")
+
+	// Warm the per-id cache for the first player through the single lookup
+	_, err := suite.service.GetPlayerSummaries(suite.testContext, ids[0])
+	suite.Require().NoError(err)
+
+	results, err := suite.service.GetPlayerSummariesBatch(suite.testContext, append(ids, ids[1]))
+	suite.Require().NoError(err)
+
+	suite.Len(results, 151)
+	suite.Equal("player 42", results[ids[42]].Player.PersonaName)
+	suite.Nil(results["76561198099999999"].Player)
+	suite.NotEmpty(results["76561198099999999"].Error)
+
+	// The cached player was not requested again and misses went out in chunks of 100
+	suite.Require().Len(suite.steamClient.Batches, 3)
+	suite.Len(suite.steamClient.Batches[1], 100)
+	suite.Len(suite.steamClient.Batches[2], 50)
+	suite.NotContains(suite.steamClient.Batches[1], ids[0])
+
+	// Batch-fetched players are now served by the single-player lookup from cache
+	summary, err := suite.service.GetPlayerSummaries(suite.testContext, ids[120])
+	suite.Require().NoError(err)
+	suite.Equal("player 120", summary.Response.Players[0].PersonaName)
+	suite.Equal(3, suite.steamClient.Calls("GetPlayerSummaries"))
+}
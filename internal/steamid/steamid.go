@@ -0,0 +1,347 @@
+// Package steamid parses and converts the identifiers Steam uses for
+// accounts: 64-bit SteamIDs, the legacy STEAM_X:Y:Z (SteamID2) and [U:1:Z]
+// (SteamID3) forms, steamcommunity.com profile URLs and vanity names.
+package steamid
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ID is a 64-bit SteamID. From the most significant bit down it packs the
+// universe (8 bits), account type (4 bits), instance (20 bits) and account
+// ID (32 bits).
+type ID uint64
+
+type Universe uint8
+
+const (
+	UniverseInvalid  Universe = 0
+	UniversePublic   Universe = 1
+	UniverseBeta     Universe = 2
+	UniverseInternal Universe = 3
+	UniverseDev      Universe = 4
+)
+
+type AccountType uint8
+
+const (
+	AccountTypeInvalid        AccountType = 0
+	AccountTypeIndividual     AccountType = 1
+	AccountTypeMultiseat      AccountType = 2
+	AccountTypeGameServer     AccountType = 3
+	AccountTypeAnonGameServer AccountType = 4
+	AccountTypePending        AccountType = 5
+	AccountTypeContentServer  AccountType = 6
+	AccountTypeClan           AccountType = 7
+	AccountTypeChat           AccountType = 8
+	AccountTypeAnonUser       AccountType = 10
+)
+
+var universeNames = [...]string{"Invalid", "Public", "Beta", "Internal", "Dev"}
+
+func (u Universe) String() string {
+	if int(u) < len(universeNames) {
+		return universeNames[u]
+	}
+	return fmt.Sprintf("Universe(%d)", u)
+}
+
+var accountTypeNames = map[AccountType]string{
+	AccountTypeInvalid:        "Invalid",
+	AccountTypeIndividual:     "Individual",
+	AccountTypeMultiseat:      "Multiseat",
+	AccountTypeGameServer:     "GameServer",
+	AccountTypeAnonGameServer: "AnonGameServer",
+	AccountTypePending:        "Pending",
+	AccountTypeContentServer:  "ContentServer",
+	AccountTypeClan:           "Clan",
+	AccountTypeChat:           "Chat",
+	AccountTypeAnonUser:       "AnonUser",
+}
+
+func (t AccountType) String() string {
+	if name, ok := accountTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("AccountType(%d)", t)
+}
+
+// InstanceDesktop is the instance of every individual account.
+const InstanceDesktop uint32 = 1
+
+// Chat instance flags, which SteamID3 spells as the type letters c and L.
+const (
+	instanceFlagClan  uint32 = 0x80000
+	instanceFlagLobby uint32 = 0x40000
+)
+
+// ErrInvalid is wrapped by every parse error.
+var ErrInvalid = errors.New("invalid steam identifier")
+
+// New builds an ID from its parts. Instances use 20 bits; higher bits are
+// dropped.
+func New(universe Universe, accountType AccountType, instance, accountID uint32) ID {
+	return ID(uint64(universe)<<56 | uint64(accountType&0xF)<<52 | uint64(instance&0xFFFFF)<<32 | uint64(accountID))
+}
+
+// Individual returns the ID of a regular user account in the public
+// universe.
+func Individual(accountID uint32) ID {
+	return New(UniversePublic, AccountTypeIndividual, InstanceDesktop, accountID)
+}
+
+func (id ID) Universe() Universe       { return Universe(id >> 56) }
+func (id ID) AccountType() AccountType { return AccountType(id >> 52 & 0xF) }
+func (id ID) Instance() uint32         { return uint32(id >> 32 & 0xFFFFF) }
+func (id ID) AccountID() uint32        { return uint32(id) }
+
+// Valid reports whether id has a known universe and account type and, for
+// user accounts, a non-zero account ID.
+func (id ID) Valid() bool {
+	if id.Universe() == UniverseInvalid || id.Universe() > UniverseDev {
+		return false
+	}
+	switch id.AccountType() {
+	case AccountTypeInvalid, 9, 11, 12, 13, 14, 15:
+		return false
+	case AccountTypeIndividual:
+		return id.AccountID() != 0
+	}
+	return true
+}
+
+// IsIndividual reports whether id is a regular user account.
+func (id ID) IsIndividual() bool {
+	return id.Universe() == UniversePublic && id.AccountType() == AccountTypeIndividual && id.AccountID() != 0
+}
+
+// String returns the 64-bit decimal form used by the Web API.
+func (id ID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// Steam2 returns the legacy STEAM_X:Y:Z form, with X 0 for the public
+// universe as older games print it. Only individual accounts have one; for
+// anything else it returns "".
+func (id ID) Steam2() string {
+	if id.AccountType() != AccountTypeIndividual {
+		return ""
+	}
+	universe := id.Universe()
+	if universe == UniversePublic {
+		universe = UniverseInvalid
+	}
+	return fmt.Sprintf("STEAM_%d:%d:%d", universe, id.AccountID()&1, id.AccountID()>>1)
+}
+
+// Steam3 returns the [T:U:A] form, adding the instance where it isn't implied
+// by the account type.
+func (id ID) Steam3() string {
+	letter := steam3Letter(id)
+	instance := id.Instance()
+	switch {
+	case id.AccountType() == AccountTypeAnonGameServer, id.AccountType() == AccountTypeMultiseat,
+		id.AccountType() == AccountTypeIndividual && instance != InstanceDesktop:
+		return fmt.Sprintf("[%c:%d:%d:%d]", letter, id.Universe(), id.AccountID(), instance)
+	}
+	return fmt.Sprintf("[%c:%d:%d]", letter, id.Universe(), id.AccountID())
+}
+
+func steam3Letter(id ID) byte {
+	if id.AccountType() == AccountTypeChat {
+		switch {
+		case id.Instance()&instanceFlagClan != 0:
+			return 'c'
+		case id.Instance()&instanceFlagLobby != 0:
+			return 'L'
+		}
+		return 'T'
+	}
+	if letter, ok := steam3Letters[id.AccountType()]; ok {
+		return letter
+	}
+	return 'i'
+}
+
+var (
+	steam2Pattern = regexp.MustCompile(`^STEAM_([0-5]):([01]):(\d{1,10})$`)
+	steam3Pattern = regexp.MustCompile(`^\[([A-Za-z]):([0-5]):(\d{1,10})(?::(\d{1,7}))?\]$`)
+)
+
+// steam3Types maps SteamID3 type letters to account types.
+var steam3Types = map[byte]AccountType{
+	'I': AccountTypeInvalid,
+	'U': AccountTypeIndividual,
+	'M': AccountTypeMultiseat,
+	'G': AccountTypeGameServer,
+	'A': AccountTypeAnonGameServer,
+	'P': AccountTypePending,
+	'C': AccountTypeContentServer,
+	'g': AccountTypeClan,
+	'T': AccountTypeChat,
+	'L': AccountTypeChat,
+	'c': AccountTypeChat,
+	'a': AccountTypeAnonUser,
+}
+
+// steam3Letters is the inverse of steam3Types; chat IDs pick their letter
+// from the instance flags instead.
+var steam3Letters = map[AccountType]byte{
+	AccountTypeInvalid:        'I',
+	AccountTypeIndividual:     'U',
+	AccountTypeMultiseat:      'M',
+	AccountTypeGameServer:     'G',
+	AccountTypeAnonGameServer: 'A',
+	AccountTypePending:        'P',
+	AccountTypeContentServer:  'C',
+	AccountTypeClan:           'g',
+	AccountTypeAnonUser:       'a',
+}
+
+// Parse reads a SteamID in 64-bit, SteamID2 or SteamID3 form.
+func Parse(s string) (ID, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case steam2Pattern.MatchString(s):
+		return parseSteam2(s)
+	case steam3Pattern.MatchString(s):
+		return parseSteam3(s)
+	case s != "" && strings.Trim(s, "0123456789") == "":
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("%w: %q is not a 64-bit SteamID", ErrInvalid, s)
+		}
+		return ID(n), nil
+	}
+	return 0, fmt.Errorf("%w: %q is not a SteamID", ErrInvalid, s)
+}
+
+// parseSteam2 reads STEAM_X:Y:Z, where the account ID is Z*2+Y. Universe 0
+// is what older games print for the public universe.
+func parseSteam2(s string) (ID, error) {
+	m := steam2Pattern.FindStringSubmatch(s)
+	universe, _ := strconv.Atoi(m[1])
+	low, _ := strconv.ParseUint(m[2], 10, 32)
+	high, err := strconv.ParseUint(m[3], 10, 32)
+	if err != nil || high > (1<<31)-1 {
+		return 0, fmt.Errorf("%w: %q account number out of range", ErrInvalid, s)
+	}
+	if universe == 0 {
+		universe = int(UniversePublic)
+	}
+	return New(Universe(universe), AccountTypeIndividual, InstanceDesktop, uint32(high<<1|low)), nil
+}
+
+// parseSteam3 reads [T:U:A] or [T:U:A:I].
+func parseSteam3(s string) (ID, error) {
+	m := steam3Pattern.FindStringSubmatch(s)
+	accountType, ok := steam3Types[m[1][0]]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q has an unknown account type", ErrInvalid, s)
+	}
+	universe, _ := strconv.Atoi(m[2])
+	accountID, err := strconv.ParseUint(m[3], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q account ID out of range", ErrInvalid, s)
+	}
+
+	instance := uint32(0)
+	switch {
+	case m[4] != "":
+		n, err := strconv.ParseUint(m[4], 10, 32)
+		if err != nil || n > 0xFFFFF {
+			return 0, fmt.Errorf("%w: %q instance out of range", ErrInvalid, s)
+		}
+		instance = uint32(n)
+	case accountType == AccountTypeIndividual:
+		instance = InstanceDesktop
+	}
+	switch m[1][0] {
+	case 'c':
+		instance |= instanceFlagClan
+	case 'L':
+		instance |= instanceFlagLobby
+	}
+	return New(Universe(universe), accountType, instance, uint32(accountID)), nil
+}
+
+// Identifier is what a user typed to name an account: either a SteamID or a
+// vanity name that still has to be resolved through the Web API.
+type Identifier struct {
+	ID     ID
+	Vanity string
+}
+
+var vanityPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{2,32}$`)
+
+// ParseIdentifier reads any supported account identifier: a SteamID in any
+// form, a steamcommunity.com/profiles/<id> or /id/<vanity> URL, or a bare
+// vanity name. SteamIDs are converted offline; only vanity names are left
+// for the caller to resolve. A number that isn't a valid 64-bit SteamID is
+// taken as a vanity name, since vanity names may be all digits.
+func ParseIdentifier(s string) (Identifier, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Identifier{}, fmt.Errorf("%w: empty", ErrInvalid)
+	}
+
+	if kind, value, ok := profileURL(s); ok {
+		if kind == "id" {
+			return vanity(value)
+		}
+		id, err := Parse(value)
+		if err != nil {
+			return Identifier{}, err
+		}
+		if !id.Valid() {
+			return Identifier{}, fmt.Errorf("%w: %q is not a valid SteamID", ErrInvalid, value)
+		}
+		return Identifier{ID: id}, nil
+	}
+
+	id, err := Parse(s)
+	switch {
+	case err == nil && id.Valid():
+		return Identifier{ID: id}, nil
+	case err == nil && strings.Trim(s, "0123456789") != "":
+		// A well-formed SteamID2/3 that can't exist, e.g. [U:9:1]
+		return Identifier{}, fmt.Errorf("%w: %q is not a valid SteamID", ErrInvalid, s)
+	}
+	return vanity(s)
+}
+
+func vanity(name string) (Identifier, error) {
+	if !vanityPattern.MatchString(name) {
+		return Identifier{}, fmt.Errorf("%w: %q is not a SteamID, profile URL or vanity name", ErrInvalid, name)
+	}
+	return Identifier{Vanity: name}, nil
+}
+
+// profileURL splits a steamcommunity.com profile URL, with or without the
+// scheme, into "id" or "profiles" and the value after it.
+func profileURL(s string) (kind, value string, ok bool) {
+	if !strings.Contains(s, "steamcommunity.com/") {
+		return "", "", false
+	}
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", "", false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host != "steamcommunity.com" {
+		return "", "", false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || (parts[0] != "id" && parts[0] != "profiles") {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,121 @@
+package steamid_test
+
+import (
+	"testing"
+
+	"github.com/Uranury/RBK_fetchAPI/internal/steamid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	gaben := steamid.ID(76561197960287930)
+
+	tests := []struct {
+		input string
+		want  steamid.ID
+	}{
+		{input: "76561197960287930", want: gaben},
+		{input: "STEAM_0:0:11101", want: gaben},
+		{input: "STEAM_1:0:11101", want: gaben},
+		{input: "[U:1:22202]", want: gaben},
+		{input: "[U:1:22202:1]", want: gaben},
+		{input: "[g:1:4]", want: steamid.New(steamid.UniversePublic, steamid.AccountTypeClan, 0, 4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			id, err := steamid.Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, id)
+		})
+	}
+
+	assert.Equal(t, uint32(22202), gaben.AccountID())
+	assert.Equal(t, steamid.UniversePublic, gaben.Universe())
+	assert.Equal(t, steamid.AccountTypeIndividual, gaben.AccountType())
+	assert.Equal(t, uint32(1), gaben.Instance())
+	assert.Equal(t, gaben, steamid.Individual(22202))
+}
+
+func TestParseRejectsMalformed(t *testing.T) {
+	for _, input := range []string{"", "STEAM_0:2:1", "[X:1:1]", "[U:1:99999999999]", "18446744073709551616", "7656119796028793O"} {
+		_, err := steamid.Parse(input)
+		assert.ErrorIs(t, err, steamid.ErrInvalid, input)
+	}
+}
+
+func TestParseIdentifier(t *testing.T) {
+	gaben := steamid.Identifier{ID: 76561197960287930}
+
+	tests := []struct {
+		input string
+		want  steamid.Identifier
+	}{
+		{input: "76561197960287930", want: gaben},
+		{input: " STEAM_0:0:11101 ", want: gaben},
+		{input: "[U:1:22202]", want: gaben},
+		{input: "https://steamcommunity.com/profiles/76561197960287930/", want: gaben},
+		{input: "steamcommunity.com/profiles/[U:1:22202]", want: gaben},
+		{input: "http://www.steamcommunity.com/profiles/76561197960287930?l=english", want: gaben},
+		{input: "https://steamcommunity.com/id/gabelogannewell/", want: steamid.Identifier{Vanity: "gabelogannewell"}},
+		{input: "https://steamcommunity.com/id/gabelogannewell/games/?tab=all", want: steamid.Identifier{Vanity: "gabelogannewell"}},
+		{input: "gabelogannewell", want: steamid.Identifier{Vanity: "gabelogannewell"}},
+		// Too small to be a SteamID, so it can only be a vanity name
+		{input: "1234567", want: steamid.Identifier{Vanity: "1234567"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			identifier, err := steamid.ParseIdentifier(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, identifier)
+		})
+	}
+}
+
+func TestParseIdentifierRejectsMalformed(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"x",
+		"name with spaces",
+		"../../etc/passwd",
+		"[U:0:22202]",
+		"https://steamcommunity.com/profiles/garbage",
+		"https://steamcommunity.com/id/not%20valid",
+		"https://evil.example/steamcommunity.com/id/gabelogannewell",
+	} {
+		_, err := steamid.ParseIdentifier(input)
+		assert.ErrorIs(t, err, steamid.ErrInvalid, input)
+	}
+}
+
+func TestRender(t *testing.T) {
+	gaben := steamid.ID(76561197960287930)
+	assert.Equal(t, "76561197960287930", gaben.String())
+	assert.Equal(t, "STEAM_0:0:11101", gaben.Steam2())
+	assert.Equal(t, "[U:1:22202]", gaben.Steam3())
+	assert.Equal(t, "Public", gaben.Universe().String())
+	assert.Equal(t, "Individual", gaben.AccountType().String())
+
+	clan := steamid.New(steamid.UniversePublic, steamid.AccountTypeClan, 0, 4)
+	assert.Equal(t, "", clan.Steam2())
+	assert.Equal(t, "[g:1:4]", clan.Steam3())
+	assert.Equal(t, "Clan", clan.AccountType().String())
+	assert.Equal(t, "AccountType(12)", steamid.AccountType(12).String())
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, input := range []string{"[U:1:22202]", "[U:1:22202:2]", "[g:1:4]", "[A:1:123:456]", "[c:1:4]", "[L:1:4]", "[T:1:4]", "[G:1:9]"} {
+		id, err := steamid.Parse(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, input, id.Steam3())
+
+		again, err := steamid.Parse(id.String())
+		require.NoError(t, err, input)
+		assert.Equal(t, id, again)
+	}
+
+	id, err := steamid.Parse("STEAM_0:1:11101")
+	require.NoError(t, err)
+	assert.Equal(t, "STEAM_0:1:11101", id.Steam2())
+	assert.Equal(t, uint32(22203), id.AccountID())
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Uranury/RBK_fetchAPI/config"
 	_ "github.com/Uranury/RBK_fetchAPI/docs"
+	"github.com/Uranury/RBK_fetchAPI/internal/cache"
 	"github.com/Uranury/RBK_fetchAPI/internal/server"
 	"github.com/redis/go-redis/v9"
 )
@@ -18,16 +19,23 @@ import (
 func main() {
 	cfg := config.Load()
 
-	rdb := redis.NewClient(&redis.Options{
-		Addr: cfg.RedisAddr,
-		DB:   0,
-	})
+	var appCache cache.Cache
+	switch cfg.CacheBackend {
+	case "memory":
+		appCache = cache.NewMemoryCache(cfg.CacheSize)
+	default:
+		rdb := redis.NewClient(&redis.Options{
+			Addr: cfg.RedisAddr,
+			DB:   0,
+		})
 
-	if err := rdb.Ping(context.Background()).Err(); err != nil {
-		panic(err)
+		if err := rdb.Ping(context.Background()).Err(); err != nil {
+			panic(err)
+		}
+		appCache = cache.NewRedisCache(rdb)
 	}
 
-	server, err := server.NewServer(cfg, rdb)
+	server, err := server.NewServer(cfg, appCache)
 	if err != nil {
 		log.Fatalf("Couldn't сreate server: %v", err)
 	}